@@ -0,0 +1,21 @@
+/*
+The loggingcheck command checks the message numbers and details of go-logging calls.
+See https://pkg.go.dev/github.com/senzing-garage/go-logging/loggingcheck
+
+Usage:
+
+	go install github.com/senzing-garage/go-logging/cmd/loggingcheck@latest
+	go vet -vettool=$(which loggingcheck) ./...
+
+It may also be run directly, e.g. "loggingcheck ./...".
+*/
+package main
+
+import (
+	"github.com/senzing-garage/go-logging/loggingcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(loggingcheck.Analyzer)
+}
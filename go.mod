@@ -3,14 +3,18 @@ module github.com/senzing-garage/go-logging
 go 1.26.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/senzing-garage/go-helpers v0.6.15
 	github.com/senzing-garage/go-messaging v1.5.3
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/exp v0.0.0-20260218203240-3dfff04db8fa
+	golang.org/x/tools v0.50.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
 )
@@ -23,14 +23,18 @@ The BasicLogger type is for logging messages based on the following levels:
 TRACE, DEBUG, INFO, WARN, ERROR, FATAL, and PANIC.
 */
 type BasicLogger struct {
-	level   Level
-	isDebug bool
-	isError bool
-	isFatal bool
-	isInfo  bool
-	isPanic bool
-	isTrace bool
-	isWarn  bool
+	exitCode    int
+	exitFunc    func(code int)
+	fatalAction string
+	level       Level
+	isDebug     bool
+	isError     bool
+	isFatal     bool
+	isInfo      bool
+	isPanic     bool
+	isTrace     bool
+	isWarn      bool
+	panicAction string
 }
 
 // ----------------------------------------------------------------------------
@@ -76,8 +80,7 @@ func (logger *BasicLogger) Errorf(format string, v ...interface{}) Logger {
 // Fatal() logs a FATAL message.
 func (logger *BasicLogger) Fatal(v ...interface{}) Logger {
 	if logger.isFatal {
-		logger.print(LevelFatalName, v...)
-		log.Fatal("")
+		logger.act(logger.fatalAction, logger.print(LevelFatalName, v...))
 	}
 
 	return logger
@@ -86,8 +89,7 @@ func (logger *BasicLogger) Fatal(v ...interface{}) Logger {
 // Fatalf() logs a formatted FATAL message.
 func (logger *BasicLogger) Fatalf(format string, v ...interface{}) Logger {
 	if logger.isFatal {
-		logger.printf(LevelFatalName, format, v...)
-		log.Fatal("")
+		logger.act(logger.fatalAction, logger.printf(LevelFatalName, format, v...))
 	}
 
 	return logger
@@ -159,8 +161,7 @@ func (logger *BasicLogger) IsWarn() bool {
 // Panic() logs a PANIC message.
 func (logger *BasicLogger) Panic(v ...interface{}) Logger {
 	if logger.isPanic {
-		logger.print(LevelPanicName, v...)
-		log.Panic("")
+		logger.act(logger.panicAction, logger.print(LevelPanicName, v...))
 	}
 
 	return logger
@@ -169,8 +170,7 @@ func (logger *BasicLogger) Panic(v ...interface{}) Logger {
 // Panicf() logs a formatted PANIC message.
 func (logger *BasicLogger) Panicf(format string, v ...interface{}) Logger {
 	if logger.isPanic {
-		logger.printf(LevelPanicName, format, v...)
-		log.Panic("")
+		logger.act(logger.panicAction, logger.printf(LevelPanicName, format, v...))
 	}
 
 	return logger
@@ -244,10 +244,20 @@ func (logger *BasicLogger) Warnf(format string, v ...interface{}) Logger {
 // Internal methods
 // ----------------------------------------------------------------------------
 
+// Exit or panic after a FATAL or PANIC message is logged.
+func (logger *BasicLogger) act(action string, message string) {
+	switch action {
+	case ActionExit:
+		logger.exitFunc(logger.exitCode)
+	case ActionPanic:
+		panic(message)
+	}
+}
+
 func (logger *BasicLogger) print(
 	debugLevelName string,
 	messages ...interface{},
-) {
+) string {
 	var message string
 
 	_ = debugLevelName
@@ -259,13 +269,15 @@ func (logger *BasicLogger) print(
 	if err != nil {
 		panic(err)
 	}
+
+	return message
 }
 
 func (logger *BasicLogger) printf(
 	debugLevelName string,
 	format string,
 	messages ...interface{},
-) {
+) string {
 	var message string
 
 	_ = debugLevelName
@@ -281,4 +293,6 @@ func (logger *BasicLogger) printf(
 	if err != nil {
 		panic(err)
 	}
+
+	return message
 }
@@ -325,6 +325,38 @@ func TestFatal_Global(test *testing.T) {
 	require.True(test, logger.IsFatal())
 }
 
+func TestFatal_exit(test *testing.T) {
+	test.Parallel()
+
+	exitCodes := []int{}
+	testObject := logger.New(
+		logger.OptionExitCode{Value: 3},
+		logger.OptionExitFunc{Value: func(code int) { exitCodes = append(exitCodes, code) }},
+	)
+	testObject.Fatal("test")
+	testObject.Fatalf("test %s", "something")
+	assert.Equal(test, []int{3, 3}, exitCodes)
+}
+
+func TestNew_badActions(test *testing.T) {
+	test.Parallel()
+
+	assert.Panics(test, func() { logger.New(logger.OptionFatalAction{Value: "abort"}) })
+	assert.Panics(test, func() { logger.New(logger.OptionPanicAction{Value: "abort"}) })
+	assert.Panics(test, func() { logger.New(logger.OptionExitFunc{}) })
+}
+
+func TestFatal_log(test *testing.T) {
+	test.Parallel()
+
+	testObject := logger.New(
+		logger.OptionExitFunc{Value: func(code int) { test.Errorf("unexpected exit %d", code) }},
+		logger.OptionFatalAction{Value: logger.ActionLog},
+	)
+	testObject.Fatal("test")
+	testObject.Fatalf("test %s", "something")
+}
+
 // -- Panic -------------------------------------------------------------------
 
 func TestPanic(test *testing.T) {
@@ -337,6 +369,16 @@ func TestPanic(test *testing.T) {
 	require.True(test, testObject.IsPanic())
 }
 
+func TestPanic_log(test *testing.T) {
+	test.Parallel()
+
+	testObject := logger.New(logger.OptionPanicAction{Value: logger.ActionLog})
+	assert.NotPanics(test, func() { testObject.Panic("test") })
+	assert.PanicsWithValue(test, "test something", func() {
+		logger.New(logger.OptionPanicAction{Value: logger.ActionPanic}).Panicf("test %s", "something")
+	})
+}
+
 func TestPanic_Global(test *testing.T) {
 	test.Parallel()
 	logger.SetLogLevel(logger.LevelPanic)
@@ -3,6 +3,13 @@ For examples of use, see https://github.com/senzing-garage/go-logging/blob/main/
 */
 package logger
 
+import (
+	"os"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-logging/logging"
+)
+
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
@@ -42,6 +49,34 @@ type Logger interface {
 	Warnf(format string, v ...interface{}) Logger  // Log a formatted WARN message.
 }
 
+// --- Options for New() ------------------------------------------------------
+
+// The exit code used by ActionExit.  Default: 1.
+type OptionExitCode struct {
+	Value int
+}
+
+// The function ActionExit calls to end the process.  Default: os.Exit.  Tests may replace it.
+type OptionExitFunc struct {
+	Value func(code int)
+}
+
+/*
+What happens after a FATAL message is logged.  One of the ActionXxxx values.  Default: ActionExit.
+Unlike the logging package, whose default is ActionLog, Fatal() has always ended the program.
+*/
+type OptionFatalAction struct {
+	Value string
+}
+
+/*
+What happens after a PANIC message is logged.  One of the ActionXxxx values.  Default: ActionPanic.
+Unlike the logging package, whose default is ActionLog, Panic() has always panicked.
+*/
+type OptionPanicAction struct {
+	Value string
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -60,6 +95,13 @@ const (
 	LevelPanic
 )
 
+// Actions used with OptionFatalAction and OptionPanicAction.  They are the ActionXxxx values of the logging package.
+const (
+	ActionExit  = logging.ActionExit  // OptionExitFunc is called with OptionExitCode.
+	ActionLog   = logging.ActionLog   // The message is logged and the program continues.
+	ActionPanic = logging.ActionPanic // Panics with the message.
+)
+
 // Strings representing the supported logging levels.
 const (
 	LevelDebugName = "DEBUG"
@@ -104,9 +146,44 @@ var TextToLevelMap = map[string]Level{ //nolint
 // Constructors
 // ----------------------------------------------------------------------------
 
-// Create a new default instance of the logger.
-func New() *BasicLogger {
-	result := &BasicLogger{}
+/*
+The New function creates a new instance of the logger.
+Without options, FATAL messages exit the program with code 1 and PANIC messages panic.
+The options are checked like those of logging.New(); as New() returns no error, it panics if they are invalid.
+
+Input
+  - options: A list of options (usually having type OptionXxxxx) used to configure the logger.
+    Unknown ActionXxxx values and a nil OptionExitFunc are invalid.
+
+Output
+  - A logger
+*/
+func New(options ...interface{}) *BasicLogger {
+	result := &BasicLogger{
+		exitCode:    1,
+		exitFunc:    os.Exit,
+		fatalAction: ActionExit,
+		panicAction: ActionPanic,
+	}
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionExitCode:
+			result.exitCode = typedValue.Value
+		case OptionExitFunc:
+			result.exitFunc = typedValue.Value
+		case OptionFatalAction:
+			result.fatalAction = typedValue.Value
+		case OptionPanicAction:
+			result.panicAction = typedValue.Value
+		}
+	}
+
+	err := verifyActions(result)
+	if err != nil {
+		panic(err)
+	}
+
 	result.SetLogLevel(LevelInfo)
 
 	return result
@@ -121,6 +198,16 @@ func init() { //nolint
 	loggerInstance = New()
 }
 
+// The checks of the logging package.
+func verifyActions(logger *BasicLogger) error {
+	err := logging.VerifyActions(logger.fatalAction, logger.panicAction, logger.exitFunc)
+	if err != nil {
+		return wraperror.Errorf(err, "verifyActions")
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // Public functions for default logger instance.
 // ----------------------------------------------------------------------------
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"context"
+	"slices"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The levelActions type holds what happens after FATAL and PANIC records are logged.
+type levelActions struct {
+	exitCode    int
+	exitFunc    func(code int)
+	fatalAction string
+	panicAction string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Values used with OptionFatalAction and OptionPanicAction.
+var allActions = []string{ActionExit, ActionLog, ActionPanic} //nolint
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The VerifyActions function checks the values of OptionFatalAction, OptionPanicAction, and OptionExitFunc.
+The logger package checks its options with it, so both packages accept the same values.
+
+Input
+  - fatalAction: One of the ActionXxxx values.
+  - panicAction: One of the ActionXxxx values.
+  - exitFunc: The function ActionExit calls.  Must not be nil.
+
+Output
+  - error
+*/
+func VerifyActions(fatalAction string, panicAction string, exitFunc func(code int)) error {
+	if !slices.Contains(allActions, fatalAction) {
+		return wraperror.Errorf(errForPackage, "unknown fatal action: %s", fatalAction)
+	}
+
+	if !slices.Contains(allActions, panicAction) {
+		return wraperror.Errorf(errForPackage, "unknown panic action: %s", panicAction)
+	}
+
+	if exitFunc == nil {
+		return wraperror.Errorf(errForPackage, "OptionExitFunc must not be nil")
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The action for records at the level.  ActionLog for levels below FATAL.
+func (actions *levelActions) action(level slog.Level) string {
+	switch {
+	case level >= LevelPanicSlog:
+		return actions.panicAction
+	case level >= LevelFatalSlog:
+		return actions.fatalAction
+	default:
+		return ActionLog
+	}
+}
+
+/*
+Exit or panic after a FATAL or PANIC record is logged.
+Before exiting, the logger is closed so queued records and summaries are written.
+Before panicking, the logger is flushed, as the panic may be recovered, giving up after closeTimeout.
+The error panicked with is built from the details of the record,
+so the diagnostics of OptionStrict and placeholders are not logged a second time.
+*/
+func (actions *levelActions) act(
+	ctx context.Context,
+	loggingImpl *BasicLogging,
+	level slog.Level,
+	messageNumber int,
+	details []interface{},
+	transformedDetails []interface{},
+) {
+	switch actions.action(level) {
+	case ActionExit:
+		_ = loggingImpl.lifecycle.close()
+		actions.exitFunc(actions.exitCode)
+	case ActionPanic:
+		attrs := append(slices.Clone(loggingImpl.attrs), loggingImpl.contextAttrs(ctx)...)
+		errorDetails := append(slices.Clone(transformedDetails), attrsAsDetails(attrs)...)
+		text := loggingImpl.messenger.NewJSON(messageNumber, errorDetails...)
+		err := loggingImpl.newError(text, messageNumber, details, errorDetails)
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), closeTimeout)
+		_ = loggingImpl.lifecycle.flush(flushCtx)
+
+		cancel()
+		panic(err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newLevelActions(extractedValues *ExtractedValues) *levelActions {
+	return &levelActions{
+		exitCode:    extractedValues.exitCode,
+		exitFunc:    extractedValues.exitFunc,
+		fatalAction: extractedValues.fatalAction,
+		panicAction: extractedValues.panicAction,
+	}
+}
+
+func verifyActions(extractedValues *ExtractedValues) error {
+	return VerifyActions(extractedValues.fatalAction, extractedValues.panicAction, extractedValues.exitFunc)
+}
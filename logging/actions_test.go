@@ -0,0 +1,181 @@
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// The value a function panics with, or nil.
+func recovered(function func()) (result interface{}) {
+	defer func() {
+		result = recover()
+	}()
+
+	function()
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionFatalAction(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	exitCodes := []int{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionExitCode{Value: 3},
+		logging.OptionExitFunc{Value: func(code int) { exitCodes = append(exitCodes, code) }},
+		logging.OptionFatalAction{Value: logging.ActionExit},
+	)
+	require.NoError(test, err)
+
+	logger.Log(4001)
+	assert.Empty(test, exitCodes)
+
+	logger.Log(5001)
+	assert.Equal(test, []int{3}, exitCodes)
+	assert.Equal(test, `{"level":"ERROR","id":"4001"}`+"\n"+`{"level":"FATAL","id":"5001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionFatalAction_belowLogLevel(test *testing.T) {
+	test.Parallel()
+
+	exitCodes := []int{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(new(bytes.Buffer)),
+		getOptionLogLevel(logging.LevelPanicName),
+		logging.OptionExitFunc{Value: func(code int) { exitCodes = append(exitCodes, code) }},
+		logging.OptionFatalAction{Value: logging.ActionExit},
+	)
+	require.NoError(test, err)
+
+	logger.Log(5001)
+	assert.Empty(test, exitCodes)
+}
+
+func TestLogging_New_optionFatalAction_sampling(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	exitCodes := []int{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionExitFunc{Value: func(code int) { exitCodes = append(exitCodes, code) }},
+		logging.OptionFatalAction{Value: logging.ActionExit},
+		logging.OptionSampling{Value: logging.Sampling{FirstMessageNumber: 5000, LastMessageNumber: 5999, Initial: 1}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(5001)
+	logger.Log(5001)
+	assert.Equal(test, []int{1, 1}, exitCodes)
+	assert.Equal(test, 2, strings.Count(outputString.String(), `"id":"5001"`))
+}
+
+func TestLogging_New_optionPanicAction(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionFatalAction{Value: logging.ActionPanic},
+		logging.OptionPanicAction{Value: logging.ActionPanic},
+	)
+	require.NoError(test, err)
+
+	for _, messageNumber := range []int{5001, 6001} {
+		panicValue := recovered(func() { logger.Log(messageNumber, "Bob") })
+		panicErr, isError := panicValue.(error)
+		require.True(test, isError)
+		assert.Equal(test, logger.NewError(messageNumber, "Bob").Error(), panicErr.Error())
+	}
+
+	assert.Equal(test, `{"level":"FATAL","id":"5001"}`+"\n"+`{"level":"PANIC","id":"6001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionPanicAction_duplicates(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionDuplicateWindow{Value: time.Minute},
+		logging.OptionPanicAction{Value: logging.ActionPanic},
+	)
+	require.NoError(test, err)
+
+	for range 2 {
+		assert.Panics(test, func() { logger.Log(6001, "Bob") })
+	}
+
+	assert.Equal(test, 2, strings.Count(outputString.String(), `"id":"6001"`))
+}
+
+func TestLogging_New_optionPanicAction_strict(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionPanicAction{Value: logging.ActionPanic},
+		logging.OptionStrict{Value: true},
+	)
+	require.NoError(test, err)
+
+	panicValue := recovered(func() { logger.Log(6001, "Bob") })
+	require.Error(test, panicValue.(error))
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"id":"3998"`))
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"id":"6001"`))
+}
+
+func TestLogging_New_optionPanicAction_default(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New(optionOutput(new(bytes.Buffer)))
+	require.NoError(test, err)
+	assert.NotPanics(test, func() { logger.Log(6001) })
+}
+
+func TestLogging_New_optionFatalAction_bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionFatalAction{Value: "abort"})
+	require.Error(test, err)
+
+	_, err = logging.New(logging.OptionPanicAction{Value: "abort"})
+	require.Error(test, err)
+
+	_, err = logging.New(logging.OptionExitFunc{})
+	require.Error(test, err)
+}
+
+func TestVerifyActions(test *testing.T) {
+	test.Parallel()
+
+	exitFunc := func(int) {}
+	require.NoError(test, logging.VerifyActions(logging.ActionExit, logging.ActionPanic, exitFunc))
+	require.Error(test, logging.VerifyActions("abort", logging.ActionLog, exitFunc))
+	require.Error(test, logging.VerifyActions(logging.ActionLog, "abort", exitFunc))
+	require.Error(test, logging.VerifyActions(logging.ActionLog, logging.ActionLog, nil))
+}
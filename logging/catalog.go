@@ -0,0 +1,305 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+A Catalog holds the messages of a component by message number.
+Catalogs are usually loaded from a file with LoadCatalog() or LoadCatalogFS(),
+then used with OptionCatalog, e.g. NewSenzingLogger(componentID, nil, OptionCatalog{Value: catalog}).
+
+A catalog file has a "messages" object whose keys are message numbers, e.g. in JSON:
+
+	{
+	  "messages": {
+	    "2001": {"text": "Started %s", "status": "SUCCESS"},
+	    "4001": {"text": "Cannot read %s", "level": "WARN", "remediation": "Check the file permissions."}
+	  }
+	}
+*/
+type Catalog struct {
+	Messages map[int]CatalogMessage
+}
+
+/*
+A CatalogMessage describes one message of a Catalog.
+
+Fields
+  - DocumentationURL: An absolute URL of documentation for the message.
+    Logged as the "documentationUrl" attribute and returned by Error.DocumentationURL().
+  - Level: Overrides the level given by the message number, e.g. "WARN".
+  - Remediation: What to do when the message is logged.
+    Logged as the "remediation" attribute and returned by Error.Remediation().
+  - Status: The "status" of the message, as with OptionIDStatuses.
+  - Text: The message template, as with OptionIDMessages.
+*/
+type CatalogMessage struct {
+	DocumentationURL string `json:"documentationUrl,omitempty" toml:"documentationUrl" yaml:"documentationUrl"`
+	Level            string `json:"level,omitempty"            toml:"level"            yaml:"level"`
+	Remediation      string `json:"remediation,omitempty"      toml:"remediation"      yaml:"remediation"`
+	Status           string `json:"status,omitempty"           toml:"status"           yaml:"status"`
+	Text             string `json:"text"                       toml:"text"             yaml:"text"`
+}
+
+// The catalogFile type is the schema of catalog files.
+type catalogFile struct {
+	Messages map[string]CatalogMessage `json:"messages" toml:"messages" yaml:"messages"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Catalog file formats used with ParseCatalog().
+const (
+	CatalogFormatJSON = "json"
+	CatalogFormatTOML = "toml"
+	CatalogFormatYAML = "yaml"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The IDDocumentationURLs method returns the documentation URLs of the messages.
+
+Output
+  - A map of message number to documentation URL, for messages having a DocumentationURL.
+*/
+func (catalog *Catalog) IDDocumentationURLs() map[int]string {
+	return catalog.collect(func(message CatalogMessage) string { return message.DocumentationURL })
+}
+
+/*
+The IDLevels method returns the level overrides of the messages.
+
+Output
+  - A map of message number to level name, for messages having a Level.
+*/
+func (catalog *Catalog) IDLevels() map[int]string {
+	return catalog.collect(func(message CatalogMessage) string { return message.Level })
+}
+
+/*
+The IDMessages method returns the message templates, for use with OptionIDMessages.
+
+Output
+  - A map of message number to message template.
+*/
+func (catalog *Catalog) IDMessages() map[int]string {
+	return catalog.collect(func(message CatalogMessage) string { return message.Text })
+}
+
+/*
+The IDRemediations method returns the remediations of the messages.
+
+Output
+  - A map of message number to remediation, for messages having a Remediation.
+*/
+func (catalog *Catalog) IDRemediations() map[int]string {
+	return catalog.collect(func(message CatalogMessage) string { return message.Remediation })
+}
+
+/*
+The IDStatuses method returns the statuses of the messages, for use with OptionIDStatuses.
+
+Output
+  - A map of message number to status, for messages having a Status.
+*/
+func (catalog *Catalog) IDStatuses() map[int]string {
+	return catalog.collect(func(message CatalogMessage) string { return message.Status })
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LoadCatalog function reads a catalog file.
+The format is given by the file extension: ".json", ".toml", ".yaml", or ".yml".
+
+Input
+  - filename: The path of the catalog file.
+
+Output
+  - The Catalog.
+  - error
+*/
+func LoadCatalog(filename string) (*Catalog, error) {
+	return LoadCatalogFS(os.DirFS(filepath.Dir(filename)), filepath.Base(filename))
+}
+
+/*
+The LoadCatalogFS function reads a catalog file from a file system, e.g. an embed.FS.
+The format is given by the file extension: ".json", ".toml", ".yaml", or ".yml".
+
+Input
+  - fsys: The file system.
+  - name: The path of the catalog file in the file system.
+
+Output
+  - The Catalog.
+  - error
+*/
+func LoadCatalogFS(fsys fs.FS, name string) (*Catalog, error) {
+	var format string
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		format = CatalogFormatJSON
+	case ".toml":
+		format = CatalogFormatTOML
+	case ".yaml", ".yml":
+		format = CatalogFormatYAML
+	default:
+		return nil, wraperror.Errorf(errForPackage, "unknown catalog file extension: %s", name)
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "LoadCatalogFS")
+	}
+
+	result, err := ParseCatalog(data, format)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "catalog %s", name)
+	}
+
+	return result, nil
+}
+
+/*
+The ParseCatalog function parses the contents of a catalog file.
+
+Input
+  - data: The contents of the catalog file.
+  - format: One of the CatalogFormatXxxx values.
+
+Output
+  - The Catalog.
+  - error: Validation errors name the message number.
+*/
+func ParseCatalog(data []byte, format string) (*Catalog, error) {
+	var (
+		err  error
+		file catalogFile
+	)
+
+	switch format {
+	case CatalogFormatJSON:
+		err = json.Unmarshal(data, &file)
+	case CatalogFormatTOML:
+		err = toml.Unmarshal(data, &file)
+	case CatalogFormatYAML:
+		err = yaml.NewDecoder(bytes.NewReader(data)).Decode(&file)
+	default:
+		return nil, wraperror.Errorf(errForPackage, "unknown catalog format: %s", format)
+	}
+
+	if err != nil {
+		return nil, wraperror.Errorf(err, "ParseCatalog")
+	}
+
+	result := &Catalog{Messages: make(map[int]CatalogMessage, len(file.Messages))}
+
+	for _, key := range slices.Sorted(maps.Keys(file.Messages)) {
+		messageNumber, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, wraperror.Errorf(errForPackage, "catalog message ID %q is not a number", key)
+		}
+
+		if _, ok := result.Messages[messageNumber]; ok {
+			return nil, wraperror.Errorf(errForPackage, "catalog message %d is defined more than once", messageNumber)
+		}
+
+		result.Messages[messageNumber] = file.Messages[key]
+	}
+
+	err = verifyCatalog(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// A map of message number to a non-empty field of the messages.
+func (catalog *Catalog) collect(field func(message CatalogMessage) string) map[int]string {
+	result := map[int]string{}
+	if catalog == nil {
+		return result
+	}
+
+	for messageNumber, message := range catalog.Messages {
+		if value := field(message); value != "" {
+			result[messageNumber] = value
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Each message must have a text and valid level and documentation URL.  Errors name the first bad message.
+func verifyCatalog(catalog *Catalog) error {
+	if catalog == nil {
+		return nil
+	}
+
+	for _, messageNumber := range slices.Sorted(maps.Keys(catalog.Messages)) {
+		message := catalog.Messages[messageNumber]
+
+		if messageNumber < 0 {
+			return wraperror.Errorf(errForPackage, "catalog message %d has a negative message number", messageNumber)
+		}
+
+		if message.Text == "" {
+			return wraperror.Errorf(errForPackage, "catalog message %d has no text", messageNumber)
+		}
+
+		if message.Level != "" && !IsValidLogLevelName(message.Level) {
+			return wraperror.Errorf(errForPackage, "catalog message %d has unknown level: %s", messageNumber, message.Level)
+		}
+
+		if message.DocumentationURL != "" {
+			documentationURL, err := url.Parse(message.DocumentationURL)
+			if err != nil || !documentationURL.IsAbs() {
+				return wraperror.Errorf(
+					errForPackage,
+					"catalog message %d has invalid documentation URL: %s",
+					messageNumber,
+					message.DocumentationURL,
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package logging_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var catalogFilesTest = fstest.MapFS{ //nolint
+	"catalog.json": {Data: []byte(`{
+  "messages": {
+    "2001": {"text": "INFO: %s works with %s", "status": "SUCCESS"},
+    "4001": {
+      "text": "ERROR: %s works with %s",
+      "level": "WARN",
+      "remediation": "Reassign the work.",
+      "documentationUrl": "https://example.com/4001"
+    }
+  }
+}`)},
+	"catalog.toml": {Data: []byte(`
+[messages.2001]
+text = "INFO: %s works with %s"
+status = "SUCCESS"
+
+[messages.4001]
+text = "ERROR: %s works with %s"
+level = "WARN"
+remediation = "Reassign the work."
+documentationUrl = "https://example.com/4001"
+`)},
+	"catalog.yaml": {Data: []byte(`
+messages:
+  2001:
+    text: "INFO: %s works with %s"
+    status: SUCCESS
+  4001:
+    text: "ERROR: %s works with %s"
+    level: WARN
+    remediation: Reassign the work.
+    documentationUrl: https://example.com/4001
+`)},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLoadCatalogFS(test *testing.T) {
+	test.Parallel()
+
+	for _, name := range []string{"catalog.json", "catalog.toml", "catalog.yaml"} {
+		test.Run(name, func(test *testing.T) {
+			test.Parallel()
+
+			catalog, err := logging.LoadCatalogFS(catalogFilesTest, name)
+			require.NoError(test, err)
+			assert.Equal(test, map[int]logging.CatalogMessage{
+				2001: {Status: "SUCCESS", Text: "INFO: %s works with %s"},
+				4001: {
+					DocumentationURL: "https://example.com/4001",
+					Level:            logging.LevelWarnName,
+					Remediation:      "Reassign the work.",
+					Text:             "ERROR: %s works with %s",
+				},
+			}, catalog.Messages)
+			assert.Equal(test, map[int]string{2001: "SUCCESS"}, catalog.IDStatuses())
+			assert.Equal(test, map[int]string{4001: logging.LevelWarnName}, catalog.IDLevels())
+		})
+	}
+}
+
+func TestLoadCatalog(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "catalog.yml")
+	require.NoError(test, os.WriteFile(filename, catalogFilesTest["catalog.yaml"].Data, 0o600))
+
+	catalog, err := logging.LoadCatalog(filename)
+	require.NoError(test, err)
+	assert.Len(test, catalog.IDMessages(), 2)
+
+	_, err = logging.LoadCatalog(filepath.Join(test.TempDir(), "missing.json"))
+	require.Error(test, err)
+
+	_, err = logging.LoadCatalog("catalog.ini")
+	require.Error(test, err)
+}
+
+func TestParseCatalog_bad(test *testing.T) {
+	test.Parallel()
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "id", data: `{"messages": {"20x1": {"text": "A"}}}`, expected: `"20x1"`},
+		{name: "duplicate", data: `{"messages": {"2001": {"text": "A"}, "02001": {"text": "B"}}}`, expected: "2001"},
+		{name: "text", data: `{"messages": {"2002": {"status": "SUCCESS"}}}`, expected: "2002"},
+		{name: "level", data: `{"messages": {"2003": {"text": "A", "level": "LOUD"}}}`, expected: "2003"},
+		{name: "url", data: `{"messages": {"2004": {"text": "A", "documentationUrl": "docs/2004"}}}`, expected: "2004"},
+		{name: "json", data: `{"messages": [`, expected: "ParseCatalog"},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			_, err := logging.ParseCatalog([]byte(testCase.data), logging.CatalogFormatJSON)
+			require.ErrorContains(test, err, testCase.expected)
+		})
+	}
+
+	_, err := logging.ParseCatalog([]byte(`{}`), "ini")
+	require.Error(test, err)
+}
+
+func TestNewSenzingLogger_optionCatalog(test *testing.T) {
+	test.Parallel()
+
+	catalog, err := logging.LoadCatalogFS(catalogFilesTest, "catalog.json")
+	require.NoError(test, err)
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		nil,
+		logging.OptionCatalog{Value: catalog},
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text", "status"}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(4001, "Bob", "Mary")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"INFO: Bob works with Jane","id":"SZTL99972001","status":"SUCCESS"}`+"\n"+
+			`{"level":"WARN","text":"ERROR: Bob works with Mary","id":"SZTL99974001",`+
+			`"remediation":"Reassign the work.","documentationUrl":"https://example.com/4001"}`+"\n",
+		outputString.String(),
+	)
+
+	var catalogErr *logging.Error
+	require.ErrorAs(test, logger.NewError(4001, "Bob", "Mary"), &catalogErr)
+	assert.Equal(test, "Reassign the work.", catalogErr.Remediation())
+	assert.Equal(test, "https://example.com/4001", catalogErr.DocumentationURL())
+	require.ErrorAs(test, logger.NewError(2001, "Bob", "Jane"), &catalogErr)
+	assert.Empty(test, catalogErr.Remediation())
+	assert.Empty(test, catalogErr.DocumentationURL())
+
+	_, err = logging.New(logging.OptionCatalog{Value: &logging.Catalog{Messages: map[int]logging.CatalogMessage{
+		2001: {Level: "LOUD", Text: "A"},
+	}}})
+	require.ErrorContains(test, err, "2001")
+}
@@ -0,0 +1,247 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The duplicateFilter type collapses identical messages when OptionDuplicateConsecutive
+or OptionDuplicateWindow is used.
+Messages are identical if they have the same message number, details, With() attributes and groups,
+attributes from OptionContextExtractor, and attributes of an slog.Record logged through NewStdlibLogger().
+Details are compared by content: pointers are followed and errors are compared by their messages.
+*/
+type duplicateFilter struct {
+	consecutive bool
+	lastKey     string
+	mutex       sync.Mutex
+	runs        map[string]*duplicateRun
+	sweeper     *time.Timer
+	window      time.Duration
+}
+
+// The duplicateRun type counts the repeats of one logged message.
+type duplicateRun struct {
+	count         int
+	ctx           context.Context //nolint:containedctx
+	details       []interface{}
+	first         time.Time
+	last          time.Time
+	logger        *BasicLogging
+	messageNumber int
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Returns true if the message should be logged.  A nil duplicateFilter logs every message.
+If the message ends the runs of other messages, their summaries are logged first.
+The summary of a run is logged with the context of its first message.
+*/
+func (filter *duplicateFilter) allow(
+	ctx context.Context,
+	logger *BasicLogging,
+	messageNumber int,
+	details []interface{},
+) bool {
+	if filter == nil {
+		return true
+	}
+
+	key := fmt.Sprintf(
+		"%d %q %v %v %v %v",
+		messageNumber,
+		duplicateDetails(details),
+		logger.groups,
+		logger.attrs,
+		recordAttrs(ctx),
+		logger.contextAttrs(ctx),
+	)
+	now := time.Now()
+	ended := []*duplicateRun{}
+
+	filter.mutex.Lock()
+
+	if filter.consecutive && filter.lastKey != key {
+		if run, ok := filter.runs[filter.lastKey]; ok {
+			delete(filter.runs, filter.lastKey)
+			ended = append(ended, summarized(run)...)
+		}
+	}
+
+	run, ok := filter.runs[key]
+	if ok {
+		run.count++
+		run.last = now
+		filter.mutex.Unlock()
+
+		return false
+	}
+
+	run = &duplicateRun{
+		count:         1,
+		ctx:           ctx,
+		details:       details,
+		first:         now,
+		last:          now,
+		logger:        logger,
+		messageNumber: messageNumber,
+	}
+	filter.runs[key] = run
+	filter.lastKey = key
+
+	if filter.window > 0 && filter.sweeper == nil {
+		filter.sweeper = time.AfterFunc(filter.window, filter.sweep)
+	}
+
+	filter.mutex.Unlock()
+
+	for _, endedRun := range ended {
+		endedRun.logger.logDuplicateSummary(endedRun)
+	}
+
+	return true
+}
+
+// Log summaries of all runs now rather than when they end.
+func (filter *duplicateFilter) close() {
+	if filter == nil {
+		return
+	}
+
+	ended := []*duplicateRun{}
+
+	filter.mutex.Lock()
+
+	if filter.sweeper != nil {
+		filter.sweeper.Stop()
+		filter.sweeper = nil
+	}
+
+	for key, run := range filter.runs {
+		delete(filter.runs, key)
+		ended = append(ended, summarized(run)...)
+	}
+
+	filter.mutex.Unlock()
+
+	logDuplicateSummaries(ended)
+}
+
+// End the runs whose window has passed and log their summaries.  One timer serves all runs.
+func (filter *duplicateFilter) sweep() {
+	ended := []*duplicateRun{}
+	now := time.Now()
+
+	filter.mutex.Lock()
+
+	var next time.Duration
+
+	for key, run := range filter.runs {
+		remaining := run.first.Add(filter.window).Sub(now)
+		if remaining > 0 {
+			if next == 0 || remaining < next {
+				next = remaining
+			}
+
+			continue
+		}
+
+		delete(filter.runs, key)
+		ended = append(ended, summarized(run)...)
+	}
+
+	if next > 0 {
+		filter.sweeper = time.AfterFunc(next, filter.sweep)
+	} else {
+		filter.sweeper = nil
+	}
+
+	filter.mutex.Unlock()
+
+	logDuplicateSummaries(ended)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The details formatted by content, so details given by different pointers to equal values are the same.
+func duplicateDetails(details []interface{}) []string {
+	result := make([]string, 0, len(details))
+
+	for _, detail := range details {
+		if err, ok := detail.(error); ok {
+			result = append(result, err.Error())
+
+			continue
+		}
+
+		value := reflect.ValueOf(detail)
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+
+		if value.IsValid() && value.CanInterface() {
+			detail = value.Interface()
+		}
+
+		result = append(result, fmt.Sprintf("%#v", detail))
+	}
+
+	return result
+}
+
+// Log the summaries of runs in the order the runs began.
+func logDuplicateSummaries(runs []*duplicateRun) {
+	slices.SortFunc(runs, func(run1 *duplicateRun, run2 *duplicateRun) int {
+		return run1.first.Compare(run2.first)
+	})
+
+	for _, run := range runs {
+		run.logger.logDuplicateSummary(run)
+	}
+}
+
+// A duplicateFilter, or nil if duplicates are logged.
+func newDuplicateFilter(extractedValues *ExtractedValues) *duplicateFilter {
+	if !extractedValues.duplicateConsecutive && extractedValues.duplicateWindow == 0 {
+		return nil
+	}
+
+	return &duplicateFilter{
+		consecutive: extractedValues.duplicateConsecutive,
+		runs:        map[string]*duplicateRun{},
+		window:      extractedValues.duplicateWindow,
+	}
+}
+
+// The run, if it was repeated and needs a summary.
+func summarized(run *duplicateRun) []*duplicateRun {
+	if run.count > 1 {
+		return []*duplicateRun{run}
+	}
+
+	return nil
+}
+
+func verifyDuplicates(extractedValues *ExtractedValues) error {
+	if extractedValues.duplicateWindow < 0 {
+		return wraperror.Errorf(errForPackage, "duplicate window %s must not be negative", extractedValues.duplicateWindow)
+	}
+
+	return nil
+}
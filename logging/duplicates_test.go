@@ -0,0 +1,174 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionDuplicateConsecutive(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionDuplicateConsecutive{Value: true},
+		logging.OptionMessageFields{Value: []string{"id", "text", "duration"}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2001, "Bob", "Mary")
+	logger.Log(2001, "Bob", "Jane")
+
+	lines := strings.Split(strings.TrimSpace(outputString.String()), "\n")
+	require.Len(test, lines, 4)
+	assert.Equal(test, `{"level":"INFO","text":"INFO: Bob works with Jane","id":"2001"}`, lines[0])
+	assert.Contains(test, lines[1], `"text":"INFO: Bob works with Jane (repeated 2 times)","id":"2001","duration":`)
+	assert.Contains(test, lines[1], `"repeated":2,"first":"`)
+	assert.Contains(test, lines[1], `,"last":"`)
+	assert.Equal(test, `{"level":"INFO","text":"INFO: Bob works with Mary","id":"2001"}`, lines[2])
+	assert.Equal(test, `{"level":"INFO","text":"INFO: Bob works with Jane","id":"2001"}`, lines[3])
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, 4, strings.Count(outputString.String(), "\n"))
+}
+
+func TestLogging_New_optionDuplicateWindow(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionDuplicateWindow{Value: time.Hour},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2001, "Bob", "Mary")
+	logger.Log(2001, "Bob", "Jane")
+	logger.(logging.AttrLogging).With("jobID", "job-20").Log(2001, "Bob", "Jane")
+	assert.Equal(test, 3, strings.Count(outputString.String(), "\n"))
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Contains(test, outputString.String(), `"text":"INFO: Bob works with Jane (repeated once)","id":"2001","repeated":1`)
+	assert.Equal(test, 4, strings.Count(outputString.String(), "\n"))
+}
+
+func TestLogging_New_optionDuplicateWindow_pointers(test *testing.T) {
+	test.Parallel()
+
+	type entity struct {
+		EntityID int
+	}
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionDuplicateWindow{Value: time.Hour},
+	)
+	require.NoError(test, err)
+
+	logger.Log(3001, &entity{EntityID: 1}, errors.New("cannot read"))
+	logger.Log(3001, &entity{EntityID: 1}, errors.New("cannot read"))
+	logger.Log(3001, &entity{EntityID: 2}, errors.New("cannot read"))
+	assert.Equal(test, 2, strings.Count(outputString.String(), "\n"))
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"repeated":1`))
+}
+
+func TestLogging_New_optionDuplicateWindow_context(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		getOptionContextExtractors(),
+		logging.OptionDuplicateWindow{Value: time.Hour},
+	)
+	require.NoError(test, err)
+
+	contextLogger := logger.(logging.ContextLogging)
+	ctx1 := context.WithValue(context.Background(), requestIDKey, "request-1")
+	ctx2 := context.WithValue(context.Background(), requestIDKey, "request-2")
+	contextLogger.LogContext(ctx1, 2001)
+	contextLogger.LogContext(ctx2, 2001)
+	contextLogger.LogContext(ctx1, 2001)
+	logger.(logging.AttrLogging).WithGroup("job").(logging.ContextLogging).LogContext(ctx1, 2001)
+	assert.Equal(test, 3, strings.Count(outputString.String(), "\n"))
+
+	outputString.Reset()
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Contains(test, outputString.String(), `"requestID":"request-1"`)
+	assert.Contains(test, outputString.String(), `"repeated":1`)
+	assert.Equal(test, 1, strings.Count(outputString.String(), "\n"))
+}
+
+func TestLogging_New_optionDuplicateWindow_expired(test *testing.T) {
+	test.Parallel()
+
+	outputString := &syncBuffer{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionOutput{Value: outputString},
+		logging.OptionDuplicateWindow{Value: 50 * time.Millisecond},
+	)
+	require.NoError(test, err)
+
+	logger.Log(3001)
+	logger.Log(3001)
+	logger.Log(3001)
+	assert.Eventually(test, func() bool {
+		return strings.Contains(outputString.String(), `"repeated":2`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	logger.Log(3001)
+	assert.Equal(test, 3, strings.Count(outputString.String(), "\n"))
+}
+
+func TestLogging_New_optionDuplicateWindow_belowLogLevel(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		getOptionLogLevel(logging.LevelWarnName),
+		logging.OptionDuplicateWindow{Value: time.Hour},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	logger.Log(2001)
+	require.NoError(test, logger.SetLogLevel(logging.LevelInfoName))
+	logger.Log(2001)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, `{"level":"INFO","id":"2001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionDuplicateWindow_bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionDuplicateWindow{Value: -time.Second})
+	require.Error(test, err)
+}
@@ -0,0 +1,185 @@
+package logging
+
+import (
+	"slices"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The Error type is the error returned by NewError() and NewErrorContext().
+Error() returns the JSON message.
+Errors having the same message ID match with errors.Is().
+Errors passed as details are returned by Unwrap().
+*/
+type Error struct {
+	details          []interface{}
+	documentationURL string
+	id               string
+	level            string
+	messageNumber    int
+	remediation      string
+	status           string
+	text             string
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Details method returns the details passed to NewError() or NewErrorContext().
+
+Output
+  - The details.
+*/
+func (err *Error) Details() []interface{} {
+	return slices.Clone(err.details)
+}
+
+/*
+The DocumentationURL method returns the documentation URL of the message from OptionCatalog.
+
+Output
+  - The URL.  Empty if the message has no documentation URL.
+*/
+func (err *Error) DocumentationURL() string {
+	return err.documentationURL
+}
+
+/*
+The Error method returns the JSON message.
+
+Output
+  - The JSON message, as written by JSON().
+*/
+func (err *Error) Error() string {
+	return err.text
+}
+
+/*
+The ID method returns the message ID, e.g. "SZTL99992001".
+
+Output
+  - The message ID.
+*/
+func (err *Error) ID() string {
+	return err.id
+}
+
+/*
+The Is method is used by errors.Is().
+
+Input
+  - target: The error to compare.
+
+Output
+  - True, if the target is an *Error having the same message ID.
+*/
+func (err *Error) Is(target error) bool {
+	targetError, isOK := target.(*Error)
+
+	return isOK && targetError.id == err.id
+}
+
+/*
+The Level method returns the name of the level of the message.
+
+Output
+  - A level name, e.g. "ERROR".
+*/
+func (err *Error) Level() string {
+	return err.level
+}
+
+/*
+The MessageNumber method returns the message number passed to NewError() or NewErrorContext().
+
+Output
+  - The message number.
+*/
+func (err *Error) MessageNumber() int {
+	return err.messageNumber
+}
+
+/*
+The Remediation method returns what to do about the message, from OptionCatalog.
+
+Output
+  - The remediation.  Empty if the message has no remediation.
+*/
+func (err *Error) Remediation() string {
+	return err.remediation
+}
+
+/*
+The Status method returns the status of the message from OptionIDStatuses or MessageStatus.
+
+Output
+  - The status.  Empty if the message has no status.
+*/
+func (err *Error) Status() string {
+	return err.status
+}
+
+/*
+The Unwrap method is used by errors.Is() and errors.As().
+
+Output
+  - The errors passed as details.
+*/
+func (err *Error) Unwrap() []error {
+	var result []error
+
+	for _, detail := range err.details {
+		if detailError, isOK := detail.(error); isOK {
+			result = append(result, detailError)
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// An Error having the JSON text, with the ID, level, and status the messenger gives the message.
+func newError(
+	messengerImpl messenger.Messenger,
+	text string,
+	messageNumber int,
+	details []interface{},
+	transformedDetails []interface{},
+) *Error {
+	fieldDetails := append(
+		slices.Clone(transformedDetails),
+		messenger.OptionMessageFields{Value: []string{"id", "status"}},
+	)
+	_, logLevel, keyValuePairs := messengerImpl.NewSlogLevel(messageNumber, fieldDetails...)
+	logLevel = messageLevel(fieldDetails, logLevel)
+
+	result := &Error{
+		details:       slices.Clone(details),
+		level:         levelName(logLevel),
+		messageNumber: messageNumber,
+		text:          text,
+	}
+
+	for index := 0; index+1 < len(keyValuePairs); index += 2 {
+		value, _ := keyValuePairs[index+1].(string)
+
+		switch keyValuePairs[index] {
+		case "id":
+			result.id = value
+		case "status":
+			result.status = value
+		}
+	}
+
+	return result
+}
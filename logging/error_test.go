@@ -0,0 +1,85 @@
+package logging_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestError(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesTest, getOptionIDStatuses(), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	err = logger.NewError(4000, "Bob", "Jane")
+
+	var loggingError *logging.Error
+
+	require.ErrorAs(test, err, &loggingError)
+	assert.Equal(test, logger.JSON(4000, "Bob", "Jane"), err.Error())
+	assert.Equal(test, 4000, loggingError.MessageNumber())
+	assert.Equal(test, "SZTL99974000", loggingError.ID())
+	assert.Equal(test, logging.LevelErrorName, loggingError.Level())
+	assert.Equal(test, "FAILURE", loggingError.Status())
+	assert.Equal(test, []interface{}{"Bob", "Jane"}, loggingError.Details())
+	assert.Empty(test, loggingError.Unwrap())
+}
+
+func TestError_is(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesTest)
+	require.NoError(test, err)
+
+	err = logger.NewError(4001, "Bob", "Jane")
+	require.ErrorIs(test, err, logger.NewError(4001, "Mary", "Jane"))
+	require.NotErrorIs(test, err, logger.NewError(4002))
+	require.NotErrorIs(test, err, errors.New(err.Error())) //nolint:err113
+}
+
+func TestError_unwrap(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesTest)
+	require.NoError(test, err)
+
+	cause := logger.NewError(3001)
+	_, pathErr := os.Open("/no/such/file")
+	err = logger.NewError(4001, cause, "Jane", pathErr)
+
+	var loggingError *logging.Error
+
+	require.ErrorAs(test, err, &loggingError)
+	assert.Equal(test, []error{cause, pathErr}, loggingError.Unwrap())
+	require.ErrorIs(test, err, os.ErrNotExist)
+	require.ErrorIs(test, err, logger.NewError(3001))
+
+	var pathError *os.PathError
+
+	require.ErrorAs(test, err, &pathError)
+}
+
+func TestError_context(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesTest, getOptionIDStatuses())
+	require.NoError(test, err)
+
+	err = logger.(logging.ContextLogging).NewErrorContext(test.Context(), 6001, "Bob", "Jane")
+
+	var loggingError *logging.Error
+
+	require.ErrorAs(test, err, &loggingError)
+	assert.Equal(test, logging.LevelPanicName, loggingError.Level())
+	assert.Equal(test, "SZTL99976001", loggingError.ID())
+	assert.Empty(test, loggingError.Status())
+}
@@ -0,0 +1,257 @@
+package logging
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The asyncHandler type is an slog.Handler that puts records on a bounded queue.
+A background goroutine passes queued records to the wrapped handler,
+so Log() does not wait for slow outputs.
+All handlers created by WithAttrs() and WithGroup() share one queue.
+*/
+type asyncHandler struct {
+	handler slog.Handler
+	queue   *asyncQueue
+}
+
+// The asyncItem type is a record waiting to be written by the handler that received it.
+type asyncItem struct {
+	ctx     context.Context //nolint
+	handler slog.Handler
+	record  slog.Record
+}
+
+// The asyncQueue type holds queued records and the state of the background writer.
+type asyncQueue struct {
+	closeOnce    sync.Once
+	closed       bool
+	done         chan struct{}
+	dropBelow    slog.Level
+	dropped      atomic.Uint64
+	err          error
+	idle         chan struct{}
+	items        chan asyncItem
+	mutex        sync.RWMutex // Guards closed and closing items.
+	overflow     string
+	pending      int
+	pendingMutex sync.Mutex // Guards err, idle, and pending.
+	syncLevel    slog.Level
+	syncLevelSet bool
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+func (handler *asyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.handler.Enabled(ctx, level)
+}
+
+// Queue the record.  Records at or above the OptionAsyncSyncLevel are written
+// after the queue is flushed, so the order of records is kept.
+func (handler *asyncHandler) Handle(ctx context.Context, record slog.Record) error {
+	queue := handler.queue
+
+	if queue.syncLevelSet && record.Level >= queue.syncLevel {
+		_ = queue.flush(ctx)
+
+		return handler.handler.Handle(ctx, record) //nolint:wrapcheck
+	}
+
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	if queue.closed {
+		return handler.handler.Handle(ctx, record) //nolint:wrapcheck
+	}
+
+	queue.enqueue(asyncItem{
+		ctx:     context.WithoutCancel(ctx),
+		handler: handler.handler,
+		record:  record.Clone(),
+	})
+
+	return nil
+}
+
+func (handler *asyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &asyncHandler{handler: handler.handler.WithAttrs(attrs), queue: handler.queue}
+}
+
+func (handler *asyncHandler) WithGroup(name string) slog.Handler {
+	return &asyncHandler{handler: handler.handler.WithGroup(name), queue: handler.queue}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Count a record as queued.  Flush() waits until no records are queued.
+func (queue *asyncQueue) addPending() {
+	queue.pendingMutex.Lock()
+	defer queue.pendingMutex.Unlock()
+
+	if queue.pending == 0 {
+		queue.idle = make(chan struct{})
+	}
+
+	queue.pending++
+}
+
+// Flush, then stop the background writer.  Later records are written synchronously.
+func (queue *asyncQueue) close() error {
+	var err error
+
+	queue.closeOnce.Do(func() {
+		err = queue.flush(context.Background())
+
+		queue.mutex.Lock()
+		queue.closed = true
+		close(queue.items)
+		queue.mutex.Unlock()
+
+		<-queue.done
+	})
+
+	return err
+}
+
+func (queue *asyncQueue) drop() {
+	queue.dropped.Add(1)
+	queue.removePending(nil)
+}
+
+// Put an item on the queue.  If the queue is full, the overflow policy decides what happens.
+func (queue *asyncQueue) enqueue(item asyncItem) {
+	queue.addPending()
+
+	select {
+	case queue.items <- item:
+		return
+	default:
+	}
+
+	switch {
+	case queue.overflow == AsyncOverflowDropNewest,
+		queue.overflow == AsyncOverflowDropBelowLevel && item.record.Level < queue.dropBelow:
+		queue.drop()
+	case queue.overflow == AsyncOverflowDropOldest:
+		for {
+			select {
+			case queue.items <- item:
+				return
+			default:
+			}
+
+			select {
+			case <-queue.items:
+				queue.drop()
+			default:
+			}
+		}
+	default:
+		queue.items <- item
+	}
+}
+
+// Wait until all queued records are written.
+// The first error returned by the wrapped handler since the last flush is returned.
+func (queue *asyncQueue) flush(ctx context.Context) error {
+	queue.pendingMutex.Lock()
+	idle := queue.idle
+	pending := queue.pending
+	queue.pendingMutex.Unlock()
+
+	if pending > 0 {
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), "flush")
+		}
+	}
+
+	queue.pendingMutex.Lock()
+	err := queue.err
+	queue.err = nil
+	queue.pendingMutex.Unlock()
+
+	return err
+}
+
+// Count a record as written or dropped.
+func (queue *asyncQueue) removePending(err error) {
+	queue.pendingMutex.Lock()
+	defer queue.pendingMutex.Unlock()
+
+	if queue.err == nil {
+		queue.err = err
+	}
+
+	queue.pending--
+	if queue.pending == 0 {
+		close(queue.idle)
+	}
+}
+
+// The background writer.
+func (queue *asyncQueue) run() {
+	defer close(queue.done)
+
+	for item := range queue.items {
+		queue.removePending(item.handler.Handle(item.ctx, item.record))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Wrap a handler so that records are written by a background goroutine.
+func newAsyncHandler(handler slog.Handler, extractedValues *ExtractedValues) (slog.Handler, *asyncQueue) {
+	queue := &asyncQueue{
+		done:      make(chan struct{}),
+		dropBelow: TextToLevelMap[extractedValues.asyncDropBelowLevel],
+		idle:      make(chan struct{}),
+		items:     make(chan asyncItem, extractedValues.asyncQueueSize),
+		overflow:  extractedValues.asyncOverflow,
+	}
+	close(queue.idle)
+
+	queue.syncLevel, queue.syncLevelSet = TextToLevelMap[extractedValues.asyncSyncLevel]
+
+	go queue.run()
+
+	return &asyncHandler{handler: handler, queue: queue}, queue
+}
+
+func verifyAsync(extractedValues *ExtractedValues) error {
+	switch extractedValues.asyncOverflow {
+	case AsyncOverflowBlock, AsyncOverflowDropBelowLevel, AsyncOverflowDropNewest, AsyncOverflowDropOldest:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown async overflow policy: %s", extractedValues.asyncOverflow)
+	}
+
+	if extractedValues.asyncQueueSize <= 0 {
+		return wraperror.Errorf(errForPackage, "async queue size %d must be positive", extractedValues.asyncQueueSize)
+	}
+
+	if !IsValidLogLevelName(extractedValues.asyncDropBelowLevel) {
+		return wraperror.Errorf(errForPackage, "unknown error level: %s", extractedValues.asyncDropBelowLevel)
+	}
+
+	if extractedValues.asyncSyncLevel != "" && !IsValidLogLevelName(extractedValues.asyncSyncLevel) {
+		return wraperror.Errorf(errForPackage, "unknown error level: %s", extractedValues.asyncSyncLevel)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A writer that blocks until released, like a stalled disk or pipe.
+type gatedWriter struct {
+	buffer  bytes.Buffer
+	mutex   sync.Mutex
+	release chan struct{}
+	started chan struct{}
+}
+
+func (writer *gatedWriter) String() string {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.String()
+}
+
+func (writer *gatedWriter) Write(data []byte) (int, error) {
+	select {
+	case writer.started <- struct{}{}:
+	default:
+	}
+
+	<-writer.release
+
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.Write(data) //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionAsync(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		logging.OptionAsync{Value: true},
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+	)
+	require.NoError(test, err)
+
+	logger.(logging.AttrLogging).With("jobID", "job-20").Log(2001)
+	logger.Log(2002)
+	require.NoError(test, logger.(logging.LifecycleLogging).Flush(test.Context()))
+	assert.Equal(
+		test,
+		`{"level":"INFO","jobID":"job-20","id":"2001"}`+"\n"+`{"level":"INFO","id":"2002"}`+"\n",
+		outputString.String(),
+	)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+
+	logger.Log(2003)
+	assert.Contains(test, outputString.String(), `"id":"2003"`)
+	assert.Equal(test, uint64(0), logger.(logging.LifecycleLogging).DroppedRecords())
+}
+
+func TestLogging_New_optionAsyncOverflow(test *testing.T) {
+	test.Parallel()
+
+	testCases := []struct {
+		name     string
+		overflow string
+		expected []string
+	}{
+		{name: "drop-newest", overflow: logging.AsyncOverflowDropNewest, expected: []string{"2001", "2002"}},
+		{name: "drop-oldest", overflow: logging.AsyncOverflowDropOldest, expected: []string{"2001", "2004"}},
+		{name: "drop-below-level", overflow: logging.AsyncOverflowDropBelowLevel, expected: []string{"2001", "2002"}},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			writer := &gatedWriter{release: make(chan struct{}), started: make(chan struct{}, 1)}
+			logger, err := logging.New(
+				logging.OptionAsync{Value: true},
+				logging.OptionAsyncOverflow{Value: testCase.overflow},
+				logging.OptionAsyncQueueSize{Value: 1},
+				getOptionTimeHidden(),
+				logging.OptionOutput{Value: writer},
+			)
+			require.NoError(test, err)
+
+			logger.Log(2001)
+			<-writer.started
+			logger.Log(2002)
+			logger.Log(2003)
+			logger.Log(2004)
+
+			close(writer.release)
+			require.NoError(test, logger.(logging.LifecycleLogging).Close())
+			assert.Equal(test, uint64(2), logger.(logging.LifecycleLogging).DroppedRecords())
+
+			for _, id := range testCase.expected {
+				assert.Contains(test, writer.String(), `"id":"`+id+`"`)
+			}
+
+			assert.Equal(test, len(testCase.expected), strings.Count(writer.String(), "\n"))
+		})
+	}
+}
+
+func TestLogging_New_optionAsyncSyncLevel(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		logging.OptionAsync{Value: true},
+		logging.OptionAsyncSyncLevel{Value: logging.LevelFatalName},
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	logger.Log(5001)
+	assert.Equal(test, `{"level":"INFO","id":"2001"}`+"\n"+`{"level":"FATAL","id":"5001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionAsync_flushCanceled(test *testing.T) {
+	test.Parallel()
+
+	writer := &gatedWriter{release: make(chan struct{}), started: make(chan struct{}, 1)}
+	logger, err := logging.New(logging.OptionAsync{Value: true}, logging.OptionOutput{Value: writer})
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	<-writer.started
+
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+	require.ErrorContains(test, logger.(logging.LifecycleLogging).Flush(ctx), context.Canceled.Error())
+
+	close(writer.release)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Contains(test, writer.String(), `"id":"2001"`)
+}
+
+func TestLogging_New_optionAsync_badOptions(test *testing.T) {
+	test.Parallel()
+
+	for _, option := range []interface{}{
+		logging.OptionAsyncDropBelowLevel{Value: badLogLevelName},
+		logging.OptionAsyncOverflow{Value: "drop-everything"},
+		logging.OptionAsyncQueueSize{Value: 0},
+		logging.OptionAsyncSyncLevel{Value: badLogLevelName},
+	} {
+		_, err := logging.New(logging.OptionAsync{Value: true}, option)
+		require.Error(test, err)
+	}
+}
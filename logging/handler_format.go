@@ -0,0 +1,415 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A formatEncoder writes the fields of a log record to a buffer.
+type formatEncoder func(buffer *bytes.Buffer, level slog.Level, fields []slog.Attr)
+
+/*
+The formatHandler type is an slog.Handler that flattens a log record into
+a list of fields and hands them to an encoder.
+Groups are flattened into dotted keys, e.g. "group.key".
+HandlerOptions.ReplaceAttr is applied to every field, as in slog.JSONHandler.
+*/
+type formatHandler struct {
+	attrs          []slog.Attr
+	encoder        formatEncoder
+	groups         []string
+	handlerOptions *slog.HandlerOptions
+	mutex          *sync.Mutex
+	output         io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	consoleTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+	colorReset        = "\x1b[0m"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ANSI colors used by the console format.
+var levelColors = map[slog.Level]string{ //nolint
+	LevelTraceSlog: "\x1b[90m",
+	LevelDebugSlog: "\x1b[36m",
+	LevelInfoSlog:  "\x1b[32m",
+	LevelWarnSlog:  "\x1b[33m",
+	LevelErrorSlog: "\x1b[31m",
+	LevelFatalSlog: "\x1b[35m",
+	LevelPanicSlog: "\x1b[1;31m",
+}
+
+// ----------------------------------------------------------------------------
+// Constructors
+// ----------------------------------------------------------------------------
+
+// Create an slog.Handler for one of the FormatXxxx output formats.
+func newFormatHandler(format string, output io.Writer, handlerOptions *slog.HandlerOptions) slog.Handler {
+	switch format {
+	case FormatConsole:
+		return newEncodingHandler(output, handlerOptions, consoleEncoder(os.Getenv("NO_COLOR") == ""))
+	case FormatLogfmt:
+		return newEncodingHandler(output, handlerOptions, logfmtEncoder)
+	case FormatText:
+		return slog.NewTextHandler(output, handlerOptions)
+	default:
+		return slog.NewJSONHandler(output, handlerOptions)
+	}
+}
+
+func newEncodingHandler(output io.Writer, handlerOptions *slog.HandlerOptions, encoder formatEncoder) *formatHandler {
+	if handlerOptions == nil {
+		handlerOptions = &slog.HandlerOptions{}
+	}
+
+	return &formatHandler{
+		encoder:        encoder,
+		handlerOptions: handlerOptions,
+		mutex:          &sync.Mutex{},
+		output:         output,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+func (handler *formatHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	_ = ctx
+	minimumLevel := slog.LevelInfo
+
+	if handler.handlerOptions.Level != nil {
+		minimumLevel = handler.handlerOptions.Level.Level()
+	}
+
+	return level >= minimumLevel
+}
+
+func (handler *formatHandler) Handle(ctx context.Context, record slog.Record) error {
+	_ = ctx
+	fields := make([]slog.Attr, 0, record.NumAttrs()+len(handler.attrs)+3) //nolint:mnd
+
+	if !record.Time.IsZero() {
+		fields = handler.appendAttr(fields, nil, slog.Time(slog.TimeKey, record.Time))
+	}
+
+	fields = handler.appendAttr(fields, nil, slog.Any(slog.LevelKey, record.Level))
+	fields = handler.appendAttr(fields, nil, slog.String(slog.MessageKey, record.Message))
+	fields = append(fields, handler.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = handler.appendAttr(fields, handler.groups, attr)
+
+		return true
+	})
+
+	buffer := &bytes.Buffer{}
+	handler.encoder(buffer, record.Level, fields)
+	buffer.WriteByte('\n')
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	_, err := handler.output.Write(buffer.Bytes())
+
+	return err //nolint:wrapcheck
+}
+
+func (handler *formatHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.attrs = slices.Clip(handler.attrs)
+
+	for _, attr := range attrs {
+		result.attrs = handler.appendAttr(result.attrs, handler.groups, attr)
+	}
+
+	return &result
+}
+
+func (handler *formatHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+
+	result := *handler
+	result.groups = append(slices.Clip(handler.groups), name)
+
+	return &result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Resolve, replace, and flatten an attribute into fields.
+func (handler *formatHandler) appendAttr(fields []slog.Attr, groups []string, attr slog.Attr) []slog.Attr {
+	return appendFlattenedAttr(fields, groups, attr, handler.handlerOptions.ReplaceAttr)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions - encoders
+// ----------------------------------------------------------------------------
+
+// Render fields in a human-readable format:  time LEVEL [id] text key=value ...
+func consoleEncoder(colorize bool) formatEncoder {
+	return func(buffer *bytes.Buffer, level slog.Level, fields []slog.Attr) {
+		var (
+			levelName string
+			remaining = make([]slog.Attr, 0, len(fields))
+			text      string
+			timeValue string
+		)
+
+		idValue := ""
+
+		for _, field := range fields {
+			switch field.Key {
+			case slog.TimeKey:
+				timeValue = consoleTime(field.Value)
+			case slog.LevelKey:
+				levelName = field.Value.String()
+			case "id":
+				idValue = field.Value.String()
+			case "text", slog.MessageKey:
+				text = field.Value.String()
+			default:
+				remaining = append(remaining, field)
+			}
+		}
+
+		parts := []string{}
+		if timeValue != "" {
+			parts = append(parts, timeValue)
+		}
+
+		parts = append(parts, colorizeLevel(colorize, level, fmt.Sprintf("%-5s", levelName)))
+
+		if idValue != "" {
+			parts = append(parts, "["+idValue+"]")
+		}
+
+		if text != "" {
+			parts = append(parts, text)
+		}
+
+		for _, field := range remaining {
+			parts = append(parts, consoleField(field))
+		}
+
+		buffer.WriteString(strings.Join(parts, " "))
+	}
+}
+
+// Render fields as logfmt:  key=value key="quoted value" ...
+func logfmtEncoder(buffer *bytes.Buffer, level slog.Level, fields []slog.Attr) {
+	_ = level
+
+	for index, field := range fields {
+		if index > 0 {
+			buffer.WriteByte(' ')
+		}
+
+		buffer.WriteString(logfmtKey(field.Key))
+		buffer.WriteByte('=')
+		buffer.WriteString(logfmtQuote(valueAsString(field.Value)))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Resolve, replace, and flatten an attribute into fields.  Groups become dotted keys, e.g. "group.key".
+func appendFlattenedAttr(
+	fields []slog.Attr,
+	groups []string,
+	attr slog.Attr,
+	replaceAttr func(groups []string, attr slog.Attr) slog.Attr,
+) []slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupAttrs := attr.Value.Group()
+		if len(groupAttrs) == 0 {
+			return fields
+		}
+
+		if attr.Key != "" {
+			groups = append(slices.Clip(groups), attr.Key)
+		}
+
+		for _, groupAttr := range groupAttrs {
+			fields = appendFlattenedAttr(fields, groups, groupAttr, replaceAttr)
+		}
+
+		return fields
+	}
+
+	if replaceAttr != nil {
+		attr = replaceAttr(groups, attr)
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if attr.Key == "" {
+		return fields
+	}
+
+	if len(groups) > 0 {
+		attr.Key = strings.Join(groups, ".") + "." + attr.Key
+	}
+
+	return append(fields, attr)
+}
+
+func colorizeLevel(colorize bool, level slog.Level, levelName string) string {
+	if !colorize {
+		return levelName
+	}
+
+	color, ok := levelColors[level]
+	if !ok {
+		return levelName
+	}
+
+	return color + levelName + colorReset
+}
+
+func consoleField(field slog.Attr) string {
+	if field.Key != "details" {
+		return field.Key + "=" + logfmtQuote(valueAsString(field.Value))
+	}
+
+	details, ok := field.Value.Any().([]messenger.Detail)
+	if !ok {
+		return field.Key + "=" + logfmtQuote(valueAsString(field.Value))
+	}
+
+	parts := make([]string, 0, len(details))
+
+	for _, detail := range details {
+		key := detail.Key
+		if key == "" {
+			key = strconv.Itoa(int(detail.Position))
+		}
+
+		parts = append(parts, key+"="+logfmtQuote(detail.Value))
+	}
+
+	return "details[" + strings.Join(parts, " ") + "]"
+}
+
+func consoleTime(value slog.Value) string {
+	if value.Kind() == slog.KindTime {
+		return value.Time().Format(consoleTimeFormat)
+	}
+
+	return value.String()
+}
+
+// Expand message details into one field per detail, e.g. "details.1".
+func detailFields(field slog.Attr) []slog.Attr {
+	if field.Key != "details" {
+		return []slog.Attr{field}
+	}
+
+	details, ok := field.Value.Any().([]messenger.Detail)
+	if !ok {
+		return []slog.Attr{field}
+	}
+
+	result := make([]slog.Attr, 0, len(details))
+
+	for _, detail := range details {
+		key := detail.Key
+		if key == "" {
+			key = strconv.Itoa(int(detail.Position))
+		}
+
+		result = append(result, slog.String("details."+key, detail.Value))
+	}
+
+	return result
+}
+
+// Keys may not contain spaces, quotes, or equal signs.
+func logfmtKey(key string) string {
+	return strings.Map(func(character rune) rune {
+		if character == '=' || character == '"' || unicode.IsSpace(character) || !unicode.IsPrint(character) {
+			return '_'
+		}
+
+		return character
+	}, key)
+}
+
+func logfmtQuote(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	needsQuotes := strings.IndexFunc(value, func(character rune) bool {
+		return character == '=' || character == '"' || character == '\\' ||
+			unicode.IsSpace(character) || !unicode.IsPrint(character)
+	}) >= 0
+
+	if needsQuotes {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// String representation of a field value.  Structures are rendered as JSON.
+func valueAsString(value slog.Value) string {
+	switch value.Kind() {
+	case slog.KindTime:
+		return value.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		switch typedValue := value.Any().(type) {
+		case error:
+			return typedValue.Error()
+		case fmt.Stringer:
+			return typedValue.String()
+		case string:
+			return typedValue
+		default:
+			var buffer bytes.Buffer
+
+			encoder := json.NewEncoder(&buffer)
+			encoder.SetEscapeHTML(false)
+
+			if err := encoder.Encode(typedValue); err != nil {
+				return fmt.Sprintf("%+v", typedValue)
+			}
+
+			return strings.TrimSpace(buffer.String())
+		}
+	default:
+		return value.String()
+	}
+}
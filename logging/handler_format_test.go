@@ -0,0 +1,148 @@
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+var errTest = errors.New("test error")
+
+var testCasesForFormat = []struct { //nolint
+	name          string
+	format        string
+	messageNumber int
+	details       []interface{}
+	expected      string
+}{
+	{
+		name:          "format-console",
+		format:        logging.FormatConsole,
+		messageNumber: 2001,
+		details:       []interface{}{"Bob", "Jane", logging.MessageReason{Value: "A reason"}},
+		expected:      `INFO  [2001] INFO: Bob works with Jane reason="A reason" details[1=Bob 2=Jane]` + "\n",
+	},
+	{
+		name:          "format-console-trace",
+		format:        logging.FormatConsole,
+		messageNumber: 1,
+		details:       []interface{}{"Bob", "Jane"},
+		expected:      `TRACE [1] TRACE: Bob works with Jane details[1=Bob 2=Jane]` + "\n",
+	},
+	{
+		name:          "format-json",
+		format:        logging.FormatJSON,
+		messageNumber: 3001,
+		details:       []interface{}{"Bob", "Jane"},
+		expected:      `{"level":"WARN","text":"WARN: Bob works with Jane","id":"3001","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}` + "\n",
+	},
+	{
+		name:          "format-logfmt",
+		format:        logging.FormatLogfmt,
+		messageNumber: 5001,
+		details:       []interface{}{"Bob", "Jane", logging.MessageStatus{Value: "FAILED"}},
+		expected:      `level=FATAL text="FATAL: Bob works with Jane" id=5001 status=FAILED details="[{\"position\":1,\"type\":\"string\",\"value\":\"Bob\"},{\"position\":2,\"type\":\"string\",\"value\":\"Jane\"}]"` + "\n",
+	},
+	{
+		name:          "format-text",
+		format:        logging.FormatText,
+		messageNumber: 6001,
+		details:       []interface{}{"Bob", "Jane"},
+		expected:      `level=PANIC text="PANIC: Bob works with Jane" id=6001 details="[{Key: Position:1 Type:string Value:Bob ValueRaw:<nil>} {Key: Position:2 Type:string Value:Jane ValueRaw:<nil>}]"` + "\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_badFormat(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionFormat{Value: "xml"})
+	require.Error(test, err)
+}
+
+func TestLogging_New_format(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForFormat {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			outputString := new(bytes.Buffer)
+			logger, err := logging.New(
+				logging.OptionFormat{Value: testCase.format},
+				logging.OptionLogLevel{Value: logging.LevelTraceName},
+				logging.OptionMessageFields{Value: []string{"id", "text", "reason", "status", "details"}},
+				getOptionIDMessages(),
+				getOptionTimeHidden(),
+				optionOutput(outputString),
+			)
+			require.NoError(test, err)
+			logger.Log(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expected, stripColors(outputString.String()))
+		})
+	}
+}
+
+func TestLogging_New_formatConsoleWith(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		logging.OptionFormat{Value: logging.FormatConsole},
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+	)
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).WithGroup("job").With("jobID", 17, slog.Group("empty")).Log(3001, errTest)
+	assert.Equal(test, `WARN  [3001] job.jobID=17`+"\n", stripColors(outputString.String()))
+}
+
+func TestLogging_New_formatLogfmtTime(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		logging.OptionFormat{Value: logging.FormatLogfmt},
+		logging.OptionMessageFields{Value: []string{"id", "errors"}},
+		optionOutput(outputString),
+	)
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).
+		With("started", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "with space=x", "").
+		Log(4001, errTest)
+	assert.Regexp(
+		test,
+		`^time=\S+Z level=ERROR started=2026-01-02T03:04:05Z with_space_x="" id=4001 errors="\[\\"test error\\"\]"`+"\n$",
+		outputString.String(),
+	)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+var ansiColors = regexp.MustCompile("\x1b\\[[0-9;]*m") //nolint
+
+func stripColors(value string) string {
+	return ansiColors.ReplaceAllString(value, "")
+}
+
+func TestLogging_New_formatConsoleTime(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(logging.OptionFormat{Value: logging.FormatConsole}, optionOutput(outputString))
+	require.NoError(test, err)
+	logger.Log(2001)
+	assert.Regexp(test, `^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z INFO  \[2001\]`+"\n$", stripColors(outputString.String()))
+}
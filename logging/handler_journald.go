@@ -0,0 +1,301 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The journaldConfig type holds the fields that are the same in every journal entry.
+type journaldConfig struct {
+	componentID      int
+	syslogIdentifier string
+}
+
+// The journaldWriter type sends each Write() as one datagram to the journald socket.
+type journaldWriter struct {
+	address string
+	conn    net.Conn
+	mutex   sync.Mutex
+}
+
+// --- Options for NewJournaldHandler() ---------------------------------------
+
+// The path of the journald socket.  Default: "/run/systemd/journal/socket".
+type OptionJournaldAddress struct {
+	Value string
+}
+
+// Where JSON records are written if the journald socket is absent.  Default: os.Stderr.
+type OptionJournaldFallback struct {
+	Value io.Writer
+}
+
+// The SYSLOG_IDENTIFIER of journal entries.  Default: the name of the program.
+type OptionJournaldSyslogIdentifier struct {
+	Value string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	journaldAddress        = "/run/systemd/journal/socket"
+	journaldMaxFieldLength = 64
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Parses a go-messaging location, e.g. "In main() at main.go:137".
+var journaldLocationRegexp = regexp.MustCompile(`^In (.*)\(\) at (.*):(\d+)$`) //nolint
+
+// ----------------------------------------------------------------------------
+// io.WriteCloser interface methods
+// ----------------------------------------------------------------------------
+
+func (writer *journaldWriter) Close() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	if writer.conn == nil {
+		return nil
+	}
+
+	err := writer.conn.Close()
+	writer.conn = nil
+
+	if err != nil {
+		return wraperror.Errorf(err, "Close")
+	}
+
+	return nil
+}
+
+// Send one journal entry.  If journald was restarted, the socket is reopened once.
+func (writer *journaldWriter) Write(data []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	var err error
+
+	if writer.conn != nil {
+		_, err = writer.conn.Write(data)
+		if err == nil {
+			return len(data), nil
+		}
+
+		_ = writer.conn.Close()
+		writer.conn = nil
+	}
+
+	writer.conn, err = net.Dial("unixgram", writer.address)
+	if err != nil {
+		return 0, wraperror.Errorf(err, "Dial")
+	}
+
+	_, err = writer.conn.Write(data)
+	if err != nil {
+		return 0, wraperror.Errorf(err, "Write")
+	}
+
+	return len(data), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewJournaldHandler function creates an slog.Handler that writes records to systemd-journald
+using the journald native protocol.
+Use it with OptionHandler or Sink.Handler.
+
+Each record becomes a journal entry with these fields:
+  - PRIORITY: The syslog severity of the log level.
+  - MESSAGE: The message text.
+  - MESSAGE_ID: The message id, e.g. "SZTL99992001".
+  - SENZING_COMPONENT_ID: The component id from OptionComponentID.
+  - CODE_FILE, CODE_LINE, and CODE_FUNC: Parsed from the message location, if present.
+  - SENZING_DETAIL_xxx: One field per message detail, e.g. SENZING_DETAIL_1.
+  - Other message fields and attributes, with upper-case names, e.g. "jobID" becomes JOBID.
+
+If the journald socket is absent, records are written as JSON to os.Stderr.
+
+Input
+  - options: OptionJournaldXxxx values, OptionComponentID, and OptionTimeHidden.
+
+Output
+  - An slog.Handler
+  - error
+*/
+func NewJournaldHandler(options ...interface{}) (slog.Handler, error) {
+	config := &journaldConfig{
+		componentID:      componentIdentifier,
+		syslogIdentifier: filepath.Base(os.Args[0]),
+	}
+	writer := &journaldWriter{
+		address: journaldAddress,
+	}
+
+	var fallback io.Writer = os.Stderr
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionComponentID:
+			config.componentID = typedValue.Value
+		case OptionJournaldAddress:
+			writer.address = typedValue.Value
+		case OptionJournaldFallback:
+			fallback = typedValue.Value
+		case OptionJournaldSyslogIdentifier:
+			config.syslogIdentifier = typedValue.Value
+		}
+	}
+
+	handlerOptions := SlogHandlerOptions(LevelTraceSlog, options...)
+
+	conn, err := net.Dial("unixgram", writer.address)
+	if err != nil {
+		return newFormatHandler(FormatJSON, fallback, handlerOptions), nil
+	}
+
+	writer.conn = conn
+
+	return newEncodingHandler(writer, handlerOptions, journaldEncoder(config)), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Render fields as a journald native protocol entry.
+func journaldEncoder(config *journaldConfig) formatEncoder {
+	return func(buffer *bytes.Buffer, level slog.Level, fields []slog.Attr) {
+		entry := []string{
+			"PRIORITY", strconv.Itoa(syslogSeverity(level)),
+			"SENZING_COMPONENT_ID", fmt.Sprintf("%04d", config.componentID),
+			"SYSLOG_IDENTIFIER", config.syslogIdentifier,
+		}
+		idValue := ""
+		text := ""
+
+		for _, field := range fields {
+			switch field.Key {
+			case slog.TimeKey, slog.LevelKey:
+			case "id":
+				idValue = field.Value.String()
+				entry = append(entry, "MESSAGE_ID", idValue)
+			case "text", slog.MessageKey:
+				text = field.Value.String()
+			case "location":
+				entry = append(entry, journaldLocation(field.Value.String())...)
+			default:
+				entry = append(entry, journaldFields(field)...)
+			}
+		}
+
+		if text == "" {
+			text = idValue
+		}
+
+		entry = append(entry, "MESSAGE", text)
+
+		for index := 0; index < len(entry); index += 2 {
+			if index > 0 {
+				buffer.WriteByte('\n')
+			}
+
+			journaldWriteField(buffer, entry[index], entry[index+1])
+		}
+	}
+}
+
+// Field names are upper-case letters, digits, and underscores, and do not start with a digit or underscore.
+func journaldFieldName(key string) string {
+	result := strings.Map(func(character rune) rune {
+		switch {
+		case character >= 'A' && character <= 'Z', character >= '0' && character <= '9':
+			return character
+		case character >= 'a' && character <= 'z':
+			return character - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, key)
+
+	if result == "" || result[0] == '_' || (result[0] >= '0' && result[0] <= '9') {
+		result = "SENZING_" + result
+	}
+
+	if len(result) > journaldMaxFieldLength {
+		result = result[:journaldMaxFieldLength]
+	}
+
+	return result
+}
+
+// Names and values of journal fields.  Message details become SENZING_DETAIL_xxx fields.
+func journaldFields(field slog.Attr) []string {
+	details, ok := field.Value.Any().([]messenger.Detail)
+	if field.Key != "details" || !ok {
+		return []string{journaldFieldName(field.Key), valueAsString(field.Value)}
+	}
+
+	result := make([]string, 0, 2*len(details)) //nolint:mnd
+
+	for _, detail := range details {
+		key := detail.Key
+		if key == "" {
+			key = strconv.Itoa(int(detail.Position))
+		}
+
+		result = append(result, journaldFieldName("SENZING_DETAIL_"+key), detail.Value)
+	}
+
+	return result
+}
+
+// CODE_FUNC, CODE_FILE, and CODE_LINE from a go-messaging location.
+func journaldLocation(location string) []string {
+	match := journaldLocationRegexp.FindStringSubmatch(location)
+	if match == nil {
+		return []string{"SENZING_LOCATION", location}
+	}
+
+	return []string{"CODE_FUNC", match[1], "CODE_FILE", match[2], "CODE_LINE", match[3]}
+}
+
+// Values containing a newline use the binary form:  name, newline, 64-bit little-endian length, value.
+func journaldWriteField(buffer *bytes.Buffer, name string, value string) {
+	buffer.WriteString(name)
+
+	if !strings.Contains(value, "\n") {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+
+		return
+	}
+
+	buffer.WriteByte('\n')
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+}
@@ -0,0 +1,116 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_NewJournaldHandler(test *testing.T) {
+	test.Parallel()
+
+	address := filepath.Join(test.TempDir(), "journal.sock")
+	journal, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+	require.NoError(test, err)
+
+	defer journal.Close()
+
+	handler, err := logging.NewJournaldHandler(
+		logging.OptionJournaldAddress{Value: address},
+		logging.OptionJournaldSyslogIdentifier{Value: "test-app"},
+		logging.OptionComponentID{Value: componentID},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesTest,
+		logging.OptionCallerSkip{Value: 3},
+		logging.OptionHandler{Value: handler},
+		logging.OptionMessageFields{Value: []string{"id", "text", "reason", "location", "details"}},
+	)
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).
+		With("jobID", "job-20").
+		Log(4001, "Bob", "Jane\nDoe", logging.MessageReason{Value: "A reason"})
+
+	fields := readJournalEntry(test, journal)
+	assert.Equal(test, "3", fields["PRIORITY"])
+	assert.Equal(test, "ERROR: Bob works with Jane\nDoe", fields["MESSAGE"])
+	assert.Equal(test, "SZTL99974001", fields["MESSAGE_ID"])
+	assert.Equal(test, "9997", fields["SENZING_COMPONENT_ID"])
+	assert.Equal(test, "test-app", fields["SYSLOG_IDENTIFIER"])
+	assert.Equal(test, "TestLogging_NewJournaldHandler", fields["CODE_FUNC"])
+	assert.Equal(test, "handler_journald_test.go", fields["CODE_FILE"])
+	assert.Regexp(test, `^\d+$`, fields["CODE_LINE"])
+	assert.Equal(test, "A reason", fields["REASON"])
+	assert.Equal(test, "job-20", fields["JOBID"])
+	assert.Equal(test, "Bob", fields["SENZING_DETAIL_1"])
+	assert.Equal(test, "Jane\nDoe", fields["SENZING_DETAIL_2"])
+}
+
+func TestLogging_NewJournaldHandler_fallback(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	handler, err := logging.NewJournaldHandler(
+		logging.OptionJournaldAddress{Value: filepath.Join(test.TempDir(), "absent.sock")},
+		logging.OptionJournaldFallback{Value: outputString},
+		getOptionTimeHidden(),
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(2001)
+	assert.Equal(test, `{"level":"INFO","id":"2001"}`+"\n", outputString.String())
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Parse one datagram of the journald native protocol.
+func readJournalEntry(test *testing.T, journal *net.UnixConn) map[string]string {
+	test.Helper()
+
+	buffer := make([]byte, 65536) //nolint:mnd
+	_ = journal.SetReadDeadline(time.Now().Add(5 * time.Second))
+	length, err := journal.Read(buffer)
+	require.NoError(test, err)
+
+	result := map[string]string{}
+	data := buffer[:length]
+
+	for len(data) > 0 {
+		newline := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(test, newline, 0)
+
+		line := string(data[:newline])
+		data = data[newline+1:]
+
+		if name, value, found := strings.Cut(line, "="); found {
+			result[name] = value
+
+			continue
+		}
+
+		size := binary.LittleEndian.Uint64(data[:8])
+		result[line] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+
+	return result
+}
@@ -0,0 +1,147 @@
+package logging
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The levelHandler type is an slog.Handler that only passes records
+at or above the level of the leveler to the wrapped handler.
+It keeps SetLogLevel() effective for handlers supplied by callers.
+
+With a replaceAttr function, usually from SlogHandlerOptions(), it also applies the function
+to the time, message, and attributes of records, as slog handlers do with HandlerOptions.ReplaceAttr.
+The wrapped handler formats the level itself, so no attribute is added for it.
+*/
+type levelHandler struct {
+	groups      []string
+	handler     slog.Handler
+	leveler     slog.Leveler
+	replaceAttr func(groups []string, attr slog.Attr) slog.Attr
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+func (handler *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= handler.leveler.Level() && handler.handler.Enabled(ctx, level)
+}
+
+func (handler *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if handler.replaceAttr != nil {
+		record = handler.replaceRecord(record)
+	}
+
+	return handler.handler.Handle(ctx, record) //nolint:wrapcheck
+}
+
+func (handler *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.handler = handler.handler.WithAttrs(handler.replaceAttrs(handler.groups, attrs))
+
+	return &result
+}
+
+func (handler *levelHandler) WithGroup(name string) slog.Handler {
+	result := *handler
+	result.handler = handler.handler.WithGroup(name)
+
+	if name != "" {
+		result.groups = append(slices.Clip(handler.groups), name)
+	}
+
+	return &result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Apply replaceAttr to attributes.  Groups are kept, with replaceAttr applied to their members.
+func (handler *levelHandler) replaceAttrs(groups []string, attrs []slog.Attr) []slog.Attr {
+	if handler.replaceAttr == nil {
+		return attrs
+	}
+
+	result := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+
+		if attr.Value.Kind() == slog.KindGroup {
+			memberGroups := groups
+			if attr.Key != "" {
+				memberGroups = append(slices.Clip(groups), attr.Key)
+			}
+
+			members := handler.replaceAttrs(memberGroups, attr.Value.Group())
+			if len(members) > 0 {
+				result = append(result, slog.Attr{Key: attr.Key, Value: slog.GroupValue(members...)})
+			}
+
+			continue
+		}
+
+		attr = handler.replaceAttr(groups, attr)
+		if attr.Key != "" {
+			result = append(result, attr)
+		}
+	}
+
+	return result
+}
+
+// A copy of the record with replaceAttr applied.  A removed time or message becomes the zero value.
+func (handler *levelHandler) replaceRecord(record slog.Record) slog.Record {
+	var recordTime time.Time
+
+	if !record.Time.IsZero() {
+		timeAttr := handler.replaceAttr(nil, slog.Time(slog.TimeKey, record.Time))
+		if timeAttr.Key != "" && timeAttr.Value.Kind() == slog.KindTime {
+			recordTime = timeAttr.Value.Time()
+		}
+	}
+
+	var message string
+
+	messageAttr := handler.replaceAttr(nil, slog.String(slog.MessageKey, record.Message))
+	if messageAttr.Key != "" {
+		message = messageAttr.Value.String()
+	}
+
+	result := slog.NewRecord(recordTime, record.Level, message, record.PC)
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+
+		return true
+	})
+	result.AddAttrs(handler.replaceAttrs(handler.groups, attrs)...)
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// A levelHandler applying the ReplaceAttr of the handlerOptions to a caller-supplied handler.
+func newLevelHandler(handler slog.Handler, leveler slog.Leveler, handlerOptions *slog.HandlerOptions) *levelHandler {
+	result := &levelHandler{handler: handler, leveler: leveler}
+
+	if handlerOptions != nil {
+		result.replaceAttr = handlerOptions.ReplaceAttr
+	}
+
+	return result
+}
@@ -0,0 +1,267 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	stdslog "log/slog"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A MessageNumberFunc chooses the message number for a log/slog record.
+type MessageNumberFunc func(level stdslog.Level, message string) int
+
+// The loggingHandler type is a log/slog Handler that writes records using a Logging.
+// If the Logging is not an AttrLogging, attributes become details of each message.
+type loggingHandler struct {
+	attrs         []slog.Attr
+	groups        []string
+	logging       Logging
+	messageNumber MessageNumberFunc
+}
+
+// The recordAttrsKey type is the context key of the attributes of a log/slog record passed to a BasicLogging.
+type recordAttrsKey struct{}
+
+// --- Options for NewStdlibHandler() -----------------------------------------
+
+type OptionStdlibMessageNumber struct {
+	Value int
+}
+
+type OptionStdlibMessageNumberFunc struct {
+	Value MessageNumberFunc
+}
+
+// ----------------------------------------------------------------------------
+// stdslog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+func (handler *loggingHandler) Enabled(ctx context.Context, level stdslog.Level) bool {
+	if contextLogging, ok := handler.logging.(ContextLogging); ok {
+		return contextLogging.IsContext(ctx, stdlibLevelName(level))
+	}
+
+	return handler.logging.Is(stdlibLevelName(level))
+}
+
+func (handler *loggingHandler) Handle(ctx context.Context, record stdslog.Record) error {
+	details := []interface{}{
+		MessageText{Value: record.Message},
+		messenger.MessageLevel{Value: stdlibLevelName(record.Level)},
+		messenger.OptionMessageField{Value: "text"},
+	}
+
+	if record.PC != 0 {
+		details = append(details, MessageLocation{Value: pcLocation(record.PC)})
+	}
+
+	messageNumber := handler.messageNumber(record.Level, record.Message)
+	logging := handler.logging
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+
+	record.Attrs(func(attr stdslog.Attr) bool {
+		attrs = append(attrs, fromStdlibAttr(attr))
+
+		return true
+	})
+
+	attrLogging, isAttrLogging := logging.(AttrLogging)
+	_, isBasicLogging := logging.(*BasicLogging)
+
+	switch {
+	case !isAttrLogging:
+		handlerAttrs := slices.Clip(handler.attrs)
+		for _, attr := range attrs {
+			handlerAttrs = append(handlerAttrs, qualifyAttr(handler.groups, attr))
+		}
+
+		details = append(details, attrsAsDetails(handlerAttrs)...)
+	case len(attrs) == 0:
+	case isBasicLogging:
+		// A BasicLogging adds the attributes from the context, without cloning the Logging for each record.
+		ctx = context.WithValue(ctx, recordAttrsKey{}, attrs)
+	default:
+		logging = attrLogging.With(attrsAsArgs(attrs)...)
+	}
+
+	logContext(ctx, logging, messageNumber, details)
+
+	return nil
+}
+
+func (handler *loggingHandler) WithAttrs(attrs []stdslog.Attr) stdslog.Handler {
+	result := *handler
+
+	if attrLogging, ok := handler.logging.(AttrLogging); ok {
+		result.logging = attrLogging.With(attrsAsArgs(fromStdlibAttrs(attrs))...)
+
+		return &result
+	}
+
+	result.attrs = slices.Clip(handler.attrs)
+	for _, attr := range fromStdlibAttrs(attrs) {
+		result.attrs = append(result.attrs, qualifyAttr(handler.groups, attr))
+	}
+
+	return &result
+}
+
+func (handler *loggingHandler) WithGroup(name string) stdslog.Handler {
+	result := *handler
+
+	if attrLogging, ok := handler.logging.(AttrLogging); ok {
+		result.logging = attrLogging.WithGroup(name)
+	} else {
+		result.groups = append(slices.Clip(handler.groups), name)
+	}
+
+	return &result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewStdlibHandler function creates a log/slog Handler that writes records using a Logging.
+Records use the output, log level, and level names of the Logging.
+The record message becomes the "text" field and the record level becomes the "level".
+
+Input
+  - logging: The Logging which writes the records.
+  - options: OptionStdlibMessageNumber or OptionStdlibMessageNumberFunc to choose the message number.
+    By default, the message number is the lowest message number for the record level, e.g. 2000 for INFO,
+    according to the OptionIDLevelRanges and catalog levels of the Logging.
+
+Output
+  - A log/slog Handler.
+*/
+func NewStdlibHandler(logging Logging, options ...interface{}) stdslog.Handler {
+	var ranges *idLevelRanges
+	if basicLogging, ok := logging.(*BasicLogging); ok {
+		ranges = basicLogging.idLevelRanges
+	}
+
+	result := &loggingHandler{
+		logging:       logging,
+		messageNumber: levelMessageNumber(ranges.lowestMessageNumbers()),
+	}
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionStdlibMessageNumber:
+			messageNumber := typedValue.Value
+			result.messageNumber = func(level stdslog.Level, message string) int {
+				_ = level
+				_ = message
+
+				return messageNumber
+			}
+		case OptionStdlibMessageNumberFunc:
+			result.messageNumber = typedValue.Value
+		}
+	}
+
+	return result
+}
+
+/*
+The NewStdlibLogger function creates a log/slog Logger that writes records using a Logging.
+See NewStdlibHandler.
+
+Input
+  - logging: The Logging which writes the records.
+  - options: OptionStdlibMessageNumber or OptionStdlibMessageNumberFunc to choose the message number.
+
+Output
+  - A log/slog Logger.
+*/
+func NewStdlibLogger(logging Logging, options ...interface{}) *stdslog.Logger {
+	return stdslog.New(NewStdlibHandler(logging, options...))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Attributes as arguments of With().
+func attrsAsArgs(attrs []slog.Attr) []interface{} {
+	result := make([]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, attr)
+	}
+
+	return result
+}
+
+// A MessageNumberFunc choosing the lowest message number of the closest level at or below the level.
+func levelMessageNumber(messageNumbers map[string]int) MessageNumberFunc {
+	return func(level stdslog.Level, message string) int {
+		_ = message
+		result := 0
+		closest := LevelTraceSlog
+		found := false
+
+		for levelName, messageNumber := range messageNumbers {
+			rangeLevel, ok := TextToLevelMap[levelName]
+			if ok && rangeLevel <= slog.Level(level) && (!found || rangeLevel > closest) {
+				result = messageNumber
+				closest = rangeLevel
+				found = true
+			}
+		}
+
+		return result
+	}
+}
+
+// The attributes of a log/slog record, from the context of LogContext().
+func recordAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(recordAttrsKey{}).([]slog.Attr)
+
+	return attrs
+}
+
+// Log using the context, or without it if the Logging is not a ContextLogging.
+func logContext(ctx context.Context, logging Logging, messageNumber int, details []interface{}) {
+	if contextLogging, ok := logging.(ContextLogging); ok {
+		contextLogging.LogContext(ctx, messageNumber, details...)
+
+		return
+	}
+
+	logging.Log(messageNumber, details...)
+}
+
+// A location in the style of go-messaging, e.g. "In main() at main.go:137".
+func pcLocation(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	functionName := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+
+	return fmt.Sprintf("In %s() at %s:%d", functionName, filepath.Base(frame.File), frame.Line)
+}
+
+// The name of the closest level at or below the log/slog level.
+func stdlibLevelName(level stdslog.Level) string {
+	result := LevelTraceName
+	closest := LevelTraceSlog
+
+	for slogLevel, levelName := range LevelToTextMap {
+		if slogLevel <= slog.Level(level) && slogLevel >= closest {
+			closest = slogLevel
+			result = levelName
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,152 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	stdslog "log/slog"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_NewStdlibLogger(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	stdlibLogger := logging.NewStdlibLogger(logger)
+	stdlibLogger.Debug("Dropped by log level")
+	stdlibLogger.Info("A message", "count", 3)
+	stdlibLogger.Log(context.Background(), stdslog.Level(logging.LevelPanicInt), "Panic message")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"A message","count":3,"id":"2000"}`+"\n"+
+			`{"level":"PANIC","text":"Panic message","id":"6000"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewStdlibLogger_setLogLevel(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	stdlibLogger := logging.NewStdlibLogger(logger)
+	assert.False(test, stdlibLogger.Enabled(context.Background(), stdslog.Level(logging.LevelTraceInt)))
+
+	err = logger.SetLogLevel(logging.LevelTraceName)
+	require.NoError(test, err)
+	stdlibLogger.Log(context.Background(), stdslog.Level(logging.LevelTraceInt), "Trace message")
+	stdlibLogger.Log(context.Background(), stdslog.LevelInfo+2, "Between INFO and WARN")
+	assert.Equal(
+		test,
+		`{"level":"TRACE","text":"Trace message","id":"0"}`+"\n"+
+			`{"level":"INFO","text":"Between INFO and WARN","id":"2000"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewStdlibLogger_messageNumber(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesTest, optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	stdlibLogger := logging.NewStdlibLogger(logger, logging.OptionStdlibMessageNumber{Value: 2999})
+	stdlibLogger.Warn("A warning")
+	assert.Equal(test, `{"level":"WARN","text":"A warning","id":"SZTL99972999"}`+"\n", outputString.String())
+}
+
+func TestLogging_NewStdlibLogger_messageNumberFunc(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	messageNumberFunc := func(level stdslog.Level, message string) int {
+		if message == "special" {
+			return 2100
+		}
+
+		return 2000 + int(level)
+	}
+
+	stdlibLogger := logging.NewStdlibLogger(logger, logging.OptionStdlibMessageNumberFunc{Value: messageNumberFunc})
+	stdlibLogger.Info("special")
+	stdlibLogger.Error("An error")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"special","id":"2100"}`+"\n"+`{"level":"ERROR","text":"An error","id":"2008"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewStdlibLogger_optionIDLevelRanges(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(getOptionIDLevelRanges(), optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	stdlibLogger := logging.NewStdlibLogger(logger)
+	stdlibLogger.Info("A message", "count", 3)
+	stdlibLogger.Error("An error")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"A message","count":3,"id":"20000"}`+"\n"+
+			`{"level":"ERROR","text":"An error","id":"30000"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewStdlibLogger_withAttrs(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		logging.OptionMessageFields{Value: []string{"id", "location"}},
+	)
+	require.NoError(test, err)
+
+	stdlibLogger := logging.NewStdlibLogger(logger).With("component", "library").WithGroup("request")
+	stdlibLogger.Info("A message", "path", "/")
+	assert.Regexp(
+		test,
+		`^{"level":"INFO","text":"A message","component":"library","request":{"path":"/"},"id":"2000","location":"In TestLogging_NewStdlibLogger_withAttrs\(\) at handler_logging_test.go:\d+"}`+"\n$",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewStdlibLogger_withAttrsLogging(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		logging.OptionMessageFields{Value: []string{"id", "details"}},
+	)
+	require.NoError(test, err)
+
+	// Only the methods of Logging, so attributes become details.
+
+	plainLogger := struct{ logging.Logging }{Logging: logger}
+	stdlibLogger := logging.NewStdlibLogger(plainLogger).With("component", "library").WithGroup("request")
+	stdlibLogger.Info("A message", "path", "/")
+	assert.Contains(test, outputString.String(), `"text":"A message","id":"2000"`)
+	assert.Contains(test, outputString.String(), `"valueRaw":{"component":"library","request.path":"/"}`)
+}
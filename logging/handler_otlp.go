@@ -0,0 +1,706 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A TraceContextFunc returns the trace id, span id, and trace flags of the span in a context.
+// A zero trace id means there is no span.
+type TraceContextFunc func(ctx context.Context) (traceID [16]byte, spanID [8]byte, traceFlags byte)
+
+/*
+The OTLPHandler type is an slog.Handler that exports records to an OpenTelemetry collector.
+Records are queued and exported in batches by a background goroutine.
+Call Shutdown() before the program exits so that queued records are exported.
+*/
+type OTLPHandler struct {
+	attrs    []slog.Attr
+	exporter *otlpExporter
+	groups   []string
+}
+
+// The otlpExporter type is shared by an OTLPHandler and the handlers returned by its WithAttrs() and WithGroup().
+type otlpExporter struct {
+	batchInterval time.Duration
+	batchSize     int
+	client        *http.Client
+	componentID   int
+	done          chan struct{}
+	endpoint      string
+	exportMutex   sync.Mutex
+	headers       map[string]string
+	maxQueueSize  int
+	maxRetries    int
+	mutex         sync.Mutex
+	protocol      string
+	queue         []otlpRecord
+	resource      *otlpResource
+	retryInterval time.Duration
+	runContext    context.Context //nolint:containedctx
+	shutdownOnce  sync.Once
+	stopped       bool
+	stop          context.CancelFunc
+	traceContext  TraceContextFunc
+	wake          chan struct{}
+}
+
+// --- Options for NewOTLPHandler() -------------------------------------------
+
+// The maximum time records wait before being exported.  Default: 1 second.
+type OptionOTLPBatchInterval struct {
+	Value time.Duration
+}
+
+// The number of records which causes an export.  Default: 512.
+type OptionOTLPBatchSize struct {
+	Value int
+}
+
+// The base URL of the collector.  Default: OTEL_EXPORTER_OTLP_ENDPOINT, or "http://localhost:4318" (4317 for gRPC).
+type OptionOTLPEndpoint struct {
+	Value string
+}
+
+// HTTP headers (gRPC metadata) sent with each export, e.g. for authentication.
+type OptionOTLPHeaders struct {
+	Value map[string]string
+}
+
+// The number of records kept while the collector cannot be reached.  The oldest are dropped.  Default: 2048.
+type OptionOTLPMaxQueueSize struct {
+	Value int
+}
+
+// The number of times a failed export is retried.  Default: 5.
+type OptionOTLPMaxRetries struct {
+	Value int
+}
+
+// OTLPProtocolHTTPProtobuf, OTLPProtocolHTTPJSON, or OTLPProtocolGRPC.
+// Default: OTEL_EXPORTER_OTLP_PROTOCOL, or OTLPProtocolHTTPProtobuf.
+type OptionOTLPProtocol struct {
+	Value string
+}
+
+// The time before the first retry.  It doubles with each retry.  Default: 1 second.
+type OptionOTLPRetryInterval struct {
+	Value time.Duration
+}
+
+// The "service.name" resource attribute.  Default: OTEL_SERVICE_NAME, or the name of the program.
+type OptionOTLPServiceName struct {
+	Value string
+}
+
+// The maximum time of one export request.  Default: 10 seconds.
+type OptionOTLPTimeout struct {
+	Value time.Duration
+}
+
+// The TLS configuration used with "https" endpoints.
+type OptionOTLPTLSConfig struct {
+	Value *tls.Config
+}
+
+// Finds the span in the context passed to LogContext().  See TraceparentTraceContext().
+type OptionOTLPTraceContext struct {
+	Value TraceContextFunc
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values for OptionOTLPProtocol.
+const (
+	OTLPProtocolGRPC         = "grpc"
+	OTLPProtocolHTTPJSON     = "http/json"
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+)
+
+const (
+	otlpBatchInterval    = time.Second
+	otlpBatchSize        = 512
+	otlpGRPCEndpoint     = "http://localhost:4317"
+	otlpGRPCPath         = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+	otlpHTTPEndpoint     = "http://localhost:4318"
+	otlpHTTPPath         = "/v1/logs"
+	otlpMaxQueueSize     = 2048
+	otlpMaxRetries       = 5
+	otlpMaxRetryInterval = 30 * time.Second
+	otlpRetryInterval    = time.Second
+	otlpScopeName        = "github.com/senzing-garage/go-logging"
+	otlpTimeout          = 10 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Map from log level to OpenTelemetry severity number.
+var otlpSeverities = map[slog.Level]int{ //nolint
+	LevelTraceSlog: 1,  // TRACE
+	LevelDebugSlog: 5,  // DEBUG
+	LevelInfoSlog:  9,  // INFO
+	LevelWarnSlog:  13, // WARN
+	LevelErrorSlog: 17, // ERROR
+	LevelFatalSlog: 21, // FATAL
+	LevelPanicSlog: 24, // FATAL4
+}
+
+// gRPC status codes which may succeed when retried.
+var otlpRetryableGRPCStatuses = []string{"1", "4", "8", "10", "11", "14", "15"} //nolint
+
+// HTTP status codes which may succeed when retried.
+var otlpRetryableHTTPStatuses = []int{ //nolint
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Enabled method always returns true.
+Use the OTLPHandler with OptionHandler or Sink.Handler, which filter records by log level.
+*/
+func (handler *OTLPHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	_ = ctx
+	_ = level
+
+	return true
+}
+
+/*
+The Handle method queues a record for export.
+The message "id" field becomes the "senzing.message.id" attribute.
+*/
+func (handler *OTLPHandler) Handle(ctx context.Context, record slog.Record) error {
+	exporter := handler.exporter
+	now := time.Now()
+
+	otlpRecord := otlpRecord{
+		attrs:          []slog.Attr{slog.Int("senzing.component.id", exporter.componentID)},
+		body:           record.Message,
+		observedTime:   now,
+		severityNumber: otlpSeverity(record.Level),
+		severityText:   levelName(record.Level),
+		time:           record.Time,
+	}
+
+	if otlpRecord.time.IsZero() {
+		otlpRecord.time = now
+	}
+
+	otlpRecord.attrs = append(otlpRecord.attrs, handler.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == "id" {
+			attr.Key = "senzing.message.id"
+		}
+
+		for _, field := range detailFields(attr) {
+			otlpRecord.attrs = appendFlattenedAttr(otlpRecord.attrs, handler.groups, field, nil)
+		}
+
+		return true
+	})
+
+	if exporter.traceContext != nil && ctx != nil {
+		traceID, spanID, traceFlags := exporter.traceContext(ctx)
+		if traceID != [16]byte{} {
+			otlpRecord.traceID = traceID[:]
+			otlpRecord.spanID = spanID[:]
+			otlpRecord.traceFlags = traceFlags
+		}
+	}
+
+	exporter.enqueue(otlpRecord)
+
+	return nil
+}
+
+func (handler *OTLPHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.attrs = slices.Clip(handler.attrs)
+
+	for _, attr := range attrs {
+		result.attrs = appendFlattenedAttr(result.attrs, handler.groups, attr, nil)
+	}
+
+	return &result
+}
+
+func (handler *OTLPHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return handler
+	}
+
+	result := *handler
+	result.groups = append(slices.Clip(handler.groups), name)
+
+	return &result
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Flush method exports all queued records.
+
+Input
+  - ctx: Limits the time spent exporting and retrying.
+
+Output
+  - error
+*/
+func (handler *OTLPHandler) Flush(ctx context.Context) error {
+	return handler.exporter.exportAll(ctx)
+}
+
+/*
+The Shutdown method stops the background goroutine and exports all queued records.
+An export in progress in the background is canceled; its records are exported by Shutdown.
+Records handled after Shutdown are dropped.
+Calling Shutdown more than once is safe.
+
+Input
+  - ctx: Limits the time spent waiting for the background goroutine, exporting, and retrying.
+
+Output
+  - error
+*/
+func (handler *OTLPHandler) Shutdown(ctx context.Context) error {
+	exporter := handler.exporter
+
+	exporter.shutdownOnce.Do(func() {
+		exporter.mutex.Lock()
+		exporter.stopped = true
+		exporter.mutex.Unlock()
+		exporter.stop()
+	})
+
+	select {
+	case <-exporter.done:
+	case <-ctx.Done():
+		return wraperror.Errorf(ctx.Err(), "Shutdown")
+	}
+
+	return exporter.exportAll(ctx)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewOTLPHandler function creates an slog.Handler that exports records to an OpenTelemetry collector
+using OTLP/HTTP (protobuf or JSON) or OTLP/gRPC.
+Use it with OptionHandler or Sink.Handler.
+
+The log level becomes the severity number: TRACE is 1, DEBUG is 5, INFO is 9, WARN is 13, ERROR is 17,
+FATAL is 21, and PANIC is 24.
+The message id (e.g. "SZTL99992001") becomes the "senzing.message.id" attribute and the component id
+from OptionComponentID becomes the "senzing.component.id" attribute.
+The message text becomes the body.
+
+Input
+  - options: OptionOTLPXxxx values and OptionComponentID.
+
+Output
+  - An OTLPHandler
+  - error
+*/
+func NewOTLPHandler(options ...interface{}) (*OTLPHandler, error) {
+	exporter := &otlpExporter{
+		batchInterval: otlpBatchInterval,
+		batchSize:     otlpBatchSize,
+		componentID:   componentIdentifier,
+		done:          make(chan struct{}),
+		endpoint:      os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		maxQueueSize:  otlpMaxQueueSize,
+		maxRetries:    otlpMaxRetries,
+		protocol:      os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"),
+		retryInterval: otlpRetryInterval,
+		wake:          make(chan struct{}, 1),
+	}
+	exporter.runContext, exporter.stop = context.WithCancel(context.Background())
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	timeout := otlpTimeout
+
+	var tlsConfig *tls.Config
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionComponentID:
+			exporter.componentID = typedValue.Value
+		case OptionOTLPBatchInterval:
+			exporter.batchInterval = typedValue.Value
+		case OptionOTLPBatchSize:
+			exporter.batchSize = typedValue.Value
+		case OptionOTLPEndpoint:
+			exporter.endpoint = typedValue.Value
+		case OptionOTLPHeaders:
+			exporter.headers = typedValue.Value
+		case OptionOTLPMaxQueueSize:
+			exporter.maxQueueSize = typedValue.Value
+		case OptionOTLPMaxRetries:
+			exporter.maxRetries = typedValue.Value
+		case OptionOTLPProtocol:
+			exporter.protocol = typedValue.Value
+		case OptionOTLPRetryInterval:
+			exporter.retryInterval = typedValue.Value
+		case OptionOTLPServiceName:
+			serviceName = typedValue.Value
+		case OptionOTLPTimeout:
+			timeout = typedValue.Value
+		case OptionOTLPTLSConfig:
+			tlsConfig = typedValue.Value
+		case OptionOTLPTraceContext:
+			exporter.traceContext = typedValue.Value
+		}
+	}
+
+	if exporter.protocol == "" {
+		exporter.protocol = OTLPProtocolHTTPProtobuf
+	}
+
+	if serviceName == "" {
+		serviceName = filepath.Base(os.Args[0])
+	}
+
+	err := verifyOTLP(exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter.client = otlpClient(exporter.protocol, tlsConfig, timeout)
+	exporter.endpoint = otlpURL(exporter.protocol, exporter.endpoint)
+	exporter.resource = &otlpResource{
+		attrs:     []slog.Attr{slog.String("service.name", serviceName)},
+		scopeName: otlpScopeName,
+	}
+
+	go exporter.run()
+
+	return &OTLPHandler{exporter: exporter}, nil
+}
+
+/*
+The TraceparentTraceContext function returns a TraceContextFunc which reads a W3C traceparent header value,
+e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", stored in the context under contextKey.
+With OpenTelemetry, write a TraceContextFunc using trace.SpanContextFromContext() instead.
+
+Input
+  - contextKey: The key used with context.WithValue().
+
+Output
+  - A TraceContextFunc
+*/
+func TraceparentTraceContext(contextKey interface{}) TraceContextFunc {
+	return func(ctx context.Context) ([16]byte, [8]byte, byte) {
+		var (
+			traceID    [16]byte
+			spanID     [8]byte
+			traceFlags [1]byte
+		)
+
+		traceparent, isOK := ctx.Value(contextKey).(string)
+		if !isOK {
+			return traceID, spanID, 0
+		}
+
+		parts := strings.Split(traceparent, "-")
+		if len(parts) != 4 { //nolint:mnd
+			return traceID, spanID, 0
+		}
+
+		_, errTrace := hex.Decode(traceID[:], []byte(parts[1]))
+		_, errSpan := hex.Decode(spanID[:], []byte(parts[2]))
+		_, errFlags := hex.Decode(traceFlags[:], []byte(parts[3]))
+
+		if errTrace != nil || errSpan != nil || errFlags != nil {
+			return [16]byte{}, [8]byte{}, 0
+		}
+
+		return traceID, spanID, traceFlags[0]
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (exporter *otlpExporter) enqueue(record otlpRecord) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	if exporter.stopped {
+		return
+	}
+
+	exporter.queue = append(exporter.queue, record)
+	if len(exporter.queue) > exporter.maxQueueSize {
+		exporter.queue = exporter.queue[len(exporter.queue)-exporter.maxQueueSize:]
+	}
+
+	if len(exporter.queue) >= exporter.batchSize {
+		select {
+		case exporter.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+/*
+Export queued records in batches.  On failure, the batch is dropped and the error returned.
+If ctx is done, the batch is queued again, e.g. for Shutdown() to export.
+*/
+func (exporter *otlpExporter) exportAll(ctx context.Context) error {
+	exporter.exportMutex.Lock()
+	defer exporter.exportMutex.Unlock()
+
+	for {
+		exporter.mutex.Lock()
+		batch := exporter.queue[:min(len(exporter.queue), exporter.batchSize)]
+		exporter.queue = exporter.queue[len(batch):]
+		exporter.mutex.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := exporter.export(ctx, batch)
+		if err != nil {
+			if ctx.Err() != nil {
+				exporter.requeue(batch)
+			}
+
+			return err
+		}
+	}
+}
+
+// Export one batch, retrying with exponential backoff.
+func (exporter *otlpExporter) export(ctx context.Context, batch []otlpRecord) error {
+	body, err := exporter.requestBody(batch)
+	if err != nil {
+		return err
+	}
+
+	retryInterval := exporter.retryInterval
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := exporter.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= exporter.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return wraperror.Errorf(ctx.Err(), "export")
+		case <-time.After(retryInterval):
+		}
+
+		retryInterval = min(2*retryInterval, otlpMaxRetryInterval) //nolint:mnd
+	}
+}
+
+// Send one request.  Returns true if a failed request may succeed when retried.
+func (exporter *otlpExporter) post(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, exporter.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, wraperror.Errorf(err, "NewRequestWithContext")
+	}
+
+	for key, value := range exporter.headers {
+		request.Header.Set(key, value)
+	}
+
+	switch exporter.protocol {
+	case OTLPProtocolGRPC:
+		request.Header.Set("Content-Type", "application/grpc")
+		request.Header.Set("Te", "trailers")
+	case OTLPProtocolHTTPJSON:
+		request.Header.Set("Content-Type", "application/json")
+	default:
+		request.Header.Set("Content-Type", "application/x-protobuf")
+	}
+
+	response, err := exporter.client.Do(request)
+	if err != nil {
+		return true, wraperror.Errorf(err, "Do")
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return slices.Contains(otlpRetryableHTTPStatuses, response.StatusCode),
+			wraperror.Errorf(errForPackage, "OTLP export failed with HTTP status %d", response.StatusCode)
+	}
+
+	if exporter.protocol != OTLPProtocolGRPC {
+		return false, nil
+	}
+
+	grpcStatus := response.Trailer.Get("Grpc-Status")
+	if grpcStatus == "" {
+		grpcStatus = response.Header.Get("Grpc-Status")
+	}
+
+	if grpcStatus != "0" {
+		return slices.Contains(otlpRetryableGRPCStatuses, grpcStatus),
+			wraperror.Errorf(errForPackage, "OTLP export failed with gRPC status %s", grpcStatus)
+	}
+
+	return false, nil
+}
+
+// Put a batch back at the front of the queue, unless newer records have filled the queue.
+func (exporter *otlpExporter) requeue(batch []otlpRecord) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.queue = append(slices.Clone(batch), exporter.queue...)
+	if len(exporter.queue) > exporter.maxQueueSize {
+		exporter.queue = exporter.queue[len(exporter.queue)-exporter.maxQueueSize:]
+	}
+}
+
+func (exporter *otlpExporter) requestBody(batch []otlpRecord) ([]byte, error) {
+	switch exporter.protocol {
+	case OTLPProtocolGRPC:
+		message := otlpProtobuf(exporter.resource, batch)
+		frame := make([]byte, 5, 5+len(message))                    //nolint:mnd
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(message))) //nolint:gosec
+
+		return append(frame, message...), nil
+	case OTLPProtocolHTTPJSON:
+		body, err := otlpJSON(exporter.resource, batch)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "otlpJSON")
+		}
+
+		return body, nil
+	default:
+		return otlpProtobuf(exporter.resource, batch), nil
+	}
+}
+
+// Export when the batch is full or the batch interval has passed.
+// Shutdown() cancels the run context, which also ends an export in progress and its retries.
+func (exporter *otlpExporter) run() {
+	defer close(exporter.done)
+
+	ticker := time.NewTicker(exporter.batchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-exporter.runContext.Done():
+			return
+		case <-ticker.C:
+		case <-exporter.wake:
+		}
+
+		_ = exporter.exportAll(exporter.runContext)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// gRPC uses HTTP/2, without TLS for "http" endpoints.
+func otlpClient(protocol string, tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if protocol == OTLPProtocolGRPC {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP2(true)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// The severity number of the closest level at or below the log level,
+// plus the distance from it, e.g. 10 for INFO+1.
+func otlpSeverity(level slog.Level) int {
+	result := otlpSeverities[LevelTraceSlog]
+	closest := LevelTraceSlog
+
+	for slogLevel, severity := range otlpSeverities {
+		if slogLevel <= level && slogLevel >= closest {
+			closest = slogLevel
+			result = severity
+		}
+	}
+
+	return min(result+min(int(level-closest), 3), otlpSeverities[LevelPanicSlog]) //nolint:mnd
+}
+
+// The URL of the export endpoint.
+func otlpURL(protocol string, endpoint string) string {
+	path := otlpHTTPPath
+	if protocol == OTLPProtocolGRPC {
+		path = otlpGRPCPath
+	}
+
+	if endpoint == "" {
+		endpoint = otlpHTTPEndpoint
+		if protocol == OTLPProtocolGRPC {
+			endpoint = otlpGRPCEndpoint
+		}
+	}
+
+	return strings.TrimSuffix(endpoint, "/") + path
+}
+
+func verifyOTLP(exporter *otlpExporter) error {
+	switch exporter.protocol {
+	case OTLPProtocolGRPC, OTLPProtocolHTTPJSON, OTLPProtocolHTTPProtobuf:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown OTLP protocol: %s", exporter.protocol)
+	}
+
+	if exporter.batchSize <= 0 || exporter.maxQueueSize <= 0 || exporter.batchInterval <= 0 {
+		return wraperror.Errorf(errForPackage, "OTLP batch size, queue size, and batch interval must be positive")
+	}
+
+	if exporter.maxRetries < 0 || exporter.retryInterval < 0 {
+		return wraperror.Errorf(errForPackage, "OTLP retries and retry interval must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package logging_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_NewOTLPHandler_httpProtobuf(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, false)
+	handler, err := logging.NewOTLPHandler(
+		logging.OptionOTLPEndpoint{Value: collector.server.URL},
+		logging.OptionOTLPServiceName{Value: "test-service"},
+		logging.OptionOTLPTraceContext{Value: logging.TraceparentTraceContext(contextKey("traceparent"))},
+		logging.OptionComponentID{Value: componentID},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesTest,
+		logging.OptionHandler{Value: handler},
+		logging.OptionMessageFields{Value: []string{"id", "text", "details"}},
+	)
+	require.NoError(test, err)
+
+	ctx := context.WithValue(
+		context.Background(),
+		contextKey("traceparent"),
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	)
+	logger.(logging.AttrLogging).With("count", 3).(logging.ContextLogging).LogContext(ctx, 4001, "Bob", "Jane")
+	require.NoError(test, handler.Shutdown(context.Background()))
+
+	requests := collector.requests()
+	require.Len(test, requests, 1)
+	assert.Equal(test, "application/x-protobuf", requests[0].contentType)
+	assert.Equal(test, "/v1/logs", requests[0].path)
+
+	// ExportLogsServiceRequest.resource_logs.scope_logs.log_records
+
+	resourceLogs := protobufField(test, requests[0].body, 1)
+	resource := protobufField(test, resourceLogs, 1)
+	assert.Equal(test, map[string]interface{}{"service.name": "test-service"}, protobufAttributes(test, resource, 1))
+
+	logRecord := protobufField(test, protobufField(test, resourceLogs, 2), 2)
+	fields := protobufFields(test, logRecord)
+	assert.Equal(test, uint64(17), fields[2][0])
+	assert.Equal(test, "ERROR", string(fields[3][0].([]byte)))
+	assert.Equal(test, "ERROR: Bob works with Jane", string(protobufField(test, fields[5][0].([]byte), 1)))
+	assert.Equal(test, uint64(1), fields[8][0])
+	assert.Equal(test, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(fields[9][0].([]byte)))
+	assert.Equal(test, "00f067aa0ba902b7", hex.EncodeToString(fields[10][0].([]byte)))
+	assert.Equal(
+		test,
+		map[string]interface{}{
+			"senzing.component.id": int64(componentID),
+			"senzing.message.id":   "SZTL99974001",
+			"count":                int64(3),
+			"details.1":            "Bob",
+			"details.2":            "Jane",
+		},
+		protobufAttributes(test, logRecord, 6),
+	)
+}
+
+func TestLogging_NewOTLPHandler_httpJSON(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, false)
+	handler, err := logging.NewOTLPHandler(
+		logging.OptionOTLPEndpoint{Value: collector.server.URL},
+		logging.OptionOTLPProtocol{Value: logging.OTLPProtocolHTTPJSON},
+		logging.OptionOTLPHeaders{Value: map[string]string{"Authorization": "Bearer token"}},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler}, logging.OptionLogLevel{Value: logging.LevelTraceName})
+	require.NoError(test, err)
+	logger.Log(1, logging.MessageText{Value: "A trace"})
+	logger.Log(6001, logging.MessageText{Value: "A panic"})
+	require.NoError(test, handler.Flush(context.Background()))
+
+	requests := collector.requests()
+	require.Len(test, requests, 1)
+	assert.Equal(test, "application/json", requests[0].contentType)
+	assert.Equal(test, "Bearer token", requests[0].authorization)
+
+	var request struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					SeverityNumber int
+					SeverityText   string
+					Body           struct{ StringValue string }
+				}
+			}
+		}
+	}
+
+	require.NoError(test, json.Unmarshal(requests[0].body, &request))
+	logRecords := request.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(test, logRecords, 2)
+	assert.Equal(test, 1, logRecords[0].SeverityNumber)
+	assert.Equal(test, "TRACE", logRecords[0].SeverityText)
+	assert.Equal(test, "A trace", logRecords[0].Body.StringValue)
+	assert.Equal(test, 24, logRecords[1].SeverityNumber)
+	assert.Equal(test, "PANIC", logRecords[1].SeverityText)
+
+	require.NoError(test, handler.Shutdown(context.Background()))
+}
+
+func TestLogging_NewOTLPHandler_grpc(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, true)
+	handler, err := logging.NewOTLPHandler(
+		logging.OptionOTLPEndpoint{Value: collector.server.URL},
+		logging.OptionOTLPProtocol{Value: logging.OTLPProtocolGRPC},
+		logging.OptionOTLPBatchSize{Value: 2},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(2001)
+	logger.Log(2002)
+	logger.Log(2003)
+
+	// A full batch is exported without waiting for the batch interval.
+
+	assert.Eventually(test, func() bool { return len(collector.requests()) >= 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(test, handler.Shutdown(context.Background()))
+
+	requests := collector.requests()
+	require.Len(test, requests, 2)
+	assert.Equal(test, "application/grpc", requests[0].contentType)
+	assert.Equal(test, "/opentelemetry.proto.collector.logs.v1.LogsService/Export", requests[0].path)
+	assert.Equal(test, 2, requests[0].protoMajor)
+
+	scopeLogs := protobufField(test, protobufField(test, requests[0].body, 1), 2)
+	assert.Len(test, protobufFields(test, scopeLogs)[2], 2)
+}
+
+func TestLogging_NewOTLPHandler_retry(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, false)
+	collector.failures.Store(2)
+	handler, err := logging.NewOTLPHandler(
+		logging.OptionOTLPEndpoint{Value: collector.server.URL},
+		logging.OptionOTLPRetryInterval{Value: time.Millisecond},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(2001)
+	require.NoError(test, handler.Shutdown(context.Background()))
+	assert.Len(test, collector.requests(), 1)
+	assert.Equal(test, int64(3), collector.attempts.Load())
+
+	// Records handled after Shutdown are dropped.
+
+	logger.Log(2002)
+	require.NoError(test, handler.Shutdown(context.Background()))
+	assert.Len(test, collector.requests(), 1)
+}
+
+func TestLogging_NewOTLPHandler_noRetry(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, false)
+	collector.status = http.StatusBadRequest
+	handler, err := logging.NewOTLPHandler(logging.OptionOTLPEndpoint{Value: collector.server.URL})
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(2001)
+	require.Error(test, handler.Shutdown(context.Background()))
+	assert.Equal(test, int64(1), collector.attempts.Load())
+}
+
+func TestLogging_NewOTLPHandler_shutdownDeadline(test *testing.T) {
+	test.Parallel()
+
+	collector := newFakeCollector(test, false)
+	collector.failures.Store(1000)
+	handler, err := logging.NewOTLPHandler(
+		logging.OptionOTLPEndpoint{Value: collector.server.URL},
+		logging.OptionOTLPBatchInterval{Value: time.Millisecond},
+		logging.OptionOTLPRetryInterval{Value: time.Minute},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(2001)
+	require.Eventually(test, func() bool { return collector.attempts.Load() > 0 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = handler.Shutdown(ctx)
+	require.Error(test, err)
+	assert.Less(test, time.Since(start), 5*time.Second)
+}
+
+func TestLogging_NewOTLPHandler_badOptions(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.NewOTLPHandler(logging.OptionOTLPProtocol{Value: "carrier-pigeon"})
+	require.Error(test, err)
+	_, err = logging.NewOTLPHandler(logging.OptionOTLPBatchSize{Value: 0})
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal types
+// ----------------------------------------------------------------------------
+
+// A fakeCollector records OTLP export requests.
+type fakeCollector struct {
+	attempts atomic.Int64
+	failures atomic.Int64
+	mutex    sync.Mutex
+	received []collectedRequest
+	server   *httptest.Server
+	status   int
+	useGRPC  bool
+}
+
+type collectedRequest struct {
+	authorization string
+	body          []byte
+	contentType   string
+	path          string
+	protoMajor    int
+}
+
+func newFakeCollector(test *testing.T, useGRPC bool) *fakeCollector {
+	test.Helper()
+
+	collector := &fakeCollector{status: http.StatusOK, useGRPC: useGRPC}
+	collector.server = httptest.NewUnstartedServer(http.HandlerFunc(collector.serveHTTP))
+
+	if useGRPC {
+		collector.server.Config.Protocols = new(http.Protocols)
+		collector.server.Config.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	collector.server.Start()
+	test.Cleanup(collector.server.Close)
+
+	return collector
+}
+
+func (collector *fakeCollector) requests() []collectedRequest {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	return append([]collectedRequest{}, collector.received...)
+}
+
+func (collector *fakeCollector) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	collector.attempts.Add(1)
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	if collector.failures.Add(-1) >= 0 {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	if collector.useGRPC {
+		body = body[5:] // Remove the gRPC message prefix.
+	}
+
+	collector.mutex.Lock()
+	collector.received = append(collector.received, collectedRequest{
+		authorization: request.Header.Get("Authorization"),
+		body:          body,
+		contentType:   request.Header.Get("Content-Type"),
+		path:          request.URL.Path,
+		protoMajor:    request.ProtoMajor,
+	})
+	collector.mutex.Unlock()
+
+	if collector.useGRPC {
+		writer.Header().Set("Content-Type", "application/grpc")
+		writer.Header().Set("Trailer", "Grpc-Status")
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte{0, 0, 0, 0, 0})
+		writer.Header().Set("Grpc-Status", "0")
+
+		return
+	}
+
+	writer.WriteHeader(collector.status)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// The KeyValue attributes in a field of a protobuf message, as a map.
+func protobufAttributes(test *testing.T, message []byte, fieldNumber int) map[string]interface{} {
+	test.Helper()
+
+	result := map[string]interface{}{}
+
+	for _, keyValue := range protobufFields(test, message)[fieldNumber] {
+		keyValueFields := protobufFields(test, keyValue.([]byte))
+		key := string(keyValueFields[1][0].([]byte))
+		anyValue := protobufFields(test, keyValueFields[2][0].([]byte))
+
+		switch {
+		case len(anyValue[1]) > 0:
+			result[key] = string(anyValue[1][0].([]byte))
+		case len(anyValue[3]) > 0:
+			result[key] = int64(anyValue[3][0].(uint64))
+		default:
+			result[key] = anyValue
+		}
+	}
+
+	return result
+}
+
+// The first value of a length-delimited field.
+func protobufField(test *testing.T, message []byte, fieldNumber int) []byte {
+	test.Helper()
+
+	values := protobufFields(test, message)[fieldNumber]
+	require.NotEmpty(test, values)
+
+	return values[0].([]byte)
+}
+
+// Decode a protobuf message into a map from field number to values.
+func protobufFields(test *testing.T, message []byte) map[int][]interface{} {
+	test.Helper()
+
+	result := map[int][]interface{}{}
+
+	for len(message) > 0 {
+		tag, length := binary.Uvarint(message)
+		require.Positive(test, length)
+
+		message = message[length:]
+		fieldNumber := int(tag >> 3)
+
+		switch tag & 7 {
+		case 0:
+			value, length := binary.Uvarint(message)
+			require.Positive(test, length)
+
+			message = message[length:]
+			result[fieldNumber] = append(result[fieldNumber], value)
+		case 1:
+			result[fieldNumber] = append(result[fieldNumber], binary.LittleEndian.Uint64(message))
+			message = message[8:]
+		case 2:
+			size, length := binary.Uvarint(message)
+			require.Positive(test, length)
+
+			message = message[length:]
+			result[fieldNumber] = append(result[fieldNumber], message[:size])
+			message = message[size:]
+		case 5:
+			result[fieldNumber] = append(result[fieldNumber], uint64(binary.LittleEndian.Uint32(message)))
+			message = message[4:]
+		default:
+			test.Fatalf("unexpected wire type in tag %d", tag)
+		}
+	}
+
+	return result
+}
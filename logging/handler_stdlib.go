@@ -0,0 +1,183 @@
+package logging
+
+import (
+	"context"
+	stdslog "log/slog"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The stdlibHandler type adapts a log/slog Handler to the golang.org/x/exp/slog Handler interface.
+type stdlibHandler struct {
+	handler stdslog.Handler
+}
+
+// The stdlibLeveler type adapts a golang.org/x/exp/slog Leveler to the log/slog Leveler interface.
+type stdlibLeveler struct {
+	leveler slog.Leveler
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods
+// ----------------------------------------------------------------------------
+
+func (handler *stdlibHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.handler.Enabled(ctx, stdslog.Level(level))
+}
+
+func (handler *stdlibHandler) Handle(ctx context.Context, record slog.Record) error {
+	return handler.handler.Handle(ctx, toStdlibRecord(record)) //nolint:wrapcheck
+}
+
+func (handler *stdlibHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &stdlibHandler{handler: handler.handler.WithAttrs(toStdlibAttrs(attrs))}
+}
+
+func (handler *stdlibHandler) WithGroup(name string) slog.Handler {
+	return &stdlibHandler{handler: handler.handler.WithGroup(name)}
+}
+
+// ----------------------------------------------------------------------------
+// stdslog.Leveler interface methods
+// ----------------------------------------------------------------------------
+
+func (leveler stdlibLeveler) Level() stdslog.Level {
+	return stdslog.Level(leveler.leveler.Level())
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The StdlibSlogHandlerOptions function returns log/slog handler options that include TRACE, FATAL, and PANIC.
+It is the log/slog equivalent of SlogHandlerOptions.
+
+Input
+  - leveler: The minimum level of records to be handled.  If nil, INFO is used.
+  - options: Variadic arguments listing the options (usually having type OptionXxxxx).
+
+Output
+  - Options for log/slog handlers.
+*/
+func StdlibSlogHandlerOptions(leveler stdslog.Leveler, options ...interface{}) *stdslog.HandlerOptions {
+	if leveler == nil {
+		leveler = stdslog.Level(LevelInfoInt)
+	}
+
+	handlerOptions := SlogHandlerOptions(nil, options...)
+
+	return &stdslog.HandlerOptions{
+		Level: leveler,
+		ReplaceAttr: func(groups []string, stdlibAttr stdslog.Attr) stdslog.Attr {
+			return toStdlibAttr(handlerOptions.ReplaceAttr(groups, fromStdlibAttr(stdlibAttr)))
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func fromStdlibAttr(stdlibAttr stdslog.Attr) slog.Attr {
+	return slog.Attr{Key: stdlibAttr.Key, Value: fromStdlibValue(stdlibAttr.Value)}
+}
+
+func fromStdlibAttrs(stdlibAttrs []stdslog.Attr) []slog.Attr {
+	result := make([]slog.Attr, 0, len(stdlibAttrs))
+	for _, stdlibAttr := range stdlibAttrs {
+		result = append(result, fromStdlibAttr(stdlibAttr))
+	}
+
+	return result
+}
+
+func fromStdlibValue(stdlibValue stdslog.Value) slog.Value {
+	switch stdlibValue.Kind() {
+	case stdslog.KindBool:
+		return slog.BoolValue(stdlibValue.Bool())
+	case stdslog.KindDuration:
+		return slog.DurationValue(stdlibValue.Duration())
+	case stdslog.KindFloat64:
+		return slog.Float64Value(stdlibValue.Float64())
+	case stdslog.KindGroup:
+		return slog.GroupValue(fromStdlibAttrs(stdlibValue.Group())...)
+	case stdslog.KindInt64:
+		return slog.Int64Value(stdlibValue.Int64())
+	case stdslog.KindLogValuer:
+		return fromStdlibValue(stdlibValue.Resolve())
+	case stdslog.KindString:
+		return slog.StringValue(stdlibValue.String())
+	case stdslog.KindTime:
+		return slog.TimeValue(stdlibValue.Time())
+	case stdslog.KindUint64:
+		return slog.Uint64Value(stdlibValue.Uint64())
+	case stdslog.KindAny:
+		fallthrough
+	default:
+		if level, isOK := stdlibValue.Any().(stdslog.Level); isOK {
+			return slog.AnyValue(slog.Level(level))
+		}
+
+		return slog.AnyValue(stdlibValue.Any())
+	}
+}
+
+func toStdlibAttr(attr slog.Attr) stdslog.Attr {
+	return stdslog.Attr{Key: attr.Key, Value: toStdlibValue(attr.Value)}
+}
+
+func toStdlibAttrs(attrs []slog.Attr) []stdslog.Attr {
+	result := make([]stdslog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, toStdlibAttr(attr))
+	}
+
+	return result
+}
+
+func toStdlibRecord(record slog.Record) stdslog.Record {
+	result := stdslog.NewRecord(record.Time, stdslog.Level(record.Level), record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		result.AddAttrs(toStdlibAttr(attr))
+
+		return true
+	})
+
+	return result
+}
+
+func toStdlibValue(value slog.Value) stdslog.Value {
+	switch value.Kind() {
+	case slog.KindBool:
+		return stdslog.BoolValue(value.Bool())
+	case slog.KindDuration:
+		return stdslog.DurationValue(value.Duration())
+	case slog.KindFloat64:
+		return stdslog.Float64Value(value.Float64())
+	case slog.KindGroup:
+		return stdslog.GroupValue(toStdlibAttrs(value.Group())...)
+	case slog.KindInt64:
+		return stdslog.Int64Value(value.Int64())
+	case slog.KindLogValuer:
+		return toStdlibValue(value.Resolve())
+	case slog.KindString:
+		return stdslog.StringValue(value.String())
+	case slog.KindTime:
+		return stdslog.TimeValue(value.Time())
+	case slog.KindUint64:
+		return stdslog.Uint64Value(value.Uint64())
+	case slog.KindAny:
+		fallthrough
+	default:
+		if level, isOK := value.Any().(slog.Level); isOK {
+			return stdslog.AnyValue(stdslog.Level(level))
+		}
+
+		return stdslog.AnyValue(value.Any())
+	}
+}
@@ -0,0 +1,136 @@
+package logging_test
+
+import (
+	"bytes"
+	stdslog "log/slog"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionHandler(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	handler := slog.NewJSONHandler(outputString, &slog.HandlerOptions{Level: logging.LevelTraceSlog})
+	logger, err := logging.New(logging.OptionHandler{Value: handler}, getOptionTimeHidden())
+	require.NoError(test, err)
+
+	logger.Log(1, "Dropped by log level")
+	assert.Empty(test, outputString.String())
+
+	err = logger.SetLogLevel(logging.LevelTraceName)
+	require.NoError(test, err)
+	logger.Log(1)
+	assert.Equal(test, `{"level":"DEBUG-4","msg":"","id":"1"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionHandlerFactory(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	factory := func(leveler slog.Leveler, handlerOptions *slog.HandlerOptions) slog.Handler {
+		assert.Equal(test, logging.LevelTraceSlog, leveler.Level())
+
+		return slog.NewTextHandler(outputString, handlerOptions)
+	}
+	logger, err := logging.New(
+		logging.OptionHandlerFactory{Value: factory},
+		logging.OptionLogLevel{Value: logging.LevelTraceName},
+		getOptionTimeHidden(),
+	)
+	require.NoError(test, err)
+	logger.Log(1)
+	assert.Equal(test, "level=TRACE id=1\n", outputString.String())
+}
+
+func TestLogging_New_optionStdlibHandler(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	handler := stdslog.NewJSONHandler(outputString, &stdslog.HandlerOptions{
+		Level: stdslog.Level(logging.LevelTraceInt),
+		ReplaceAttr: func(groups []string, attr stdslog.Attr) stdslog.Attr {
+			_ = groups
+			if attr.Key == stdslog.TimeKey {
+				return stdslog.Attr{}
+			}
+
+			return attr
+		},
+	})
+	logger, err := logging.New(logging.OptionStdlibHandler{Value: handler})
+	require.NoError(test, err)
+	logger.Log(1001, "Dropped by log level")
+	logger.(logging.AttrLogging).WithGroup("job").With("jobID", 17).Log(5001)
+	assert.Equal(
+		test,
+		`{"level":"ERROR+4","msg":"","job":{"jobID":17},"id":"5001"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_New_optionStdlibHandlerFactory(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	factory := func(leveler stdslog.Leveler, handlerOptions *stdslog.HandlerOptions) stdslog.Handler {
+		assert.Equal(test, stdslog.Level(logging.LevelDebugInt), leveler.Level())
+
+		return stdslog.NewJSONHandler(outputString, handlerOptions)
+	}
+	logger, err := logging.New(
+		logging.OptionStdlibHandlerFactory{Value: factory},
+		logging.OptionLogLevel{Value: logging.LevelDebugName},
+		getOptionTimeHidden(),
+	)
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).With(
+		"bool", true,
+		"duration", time.Second,
+		"float", 1.5,
+		slog.Group("group", slog.Group("inner", "int", 7)),
+		"started", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		"uint", uint64(3),
+		"level", logging.LevelWarnSlog,
+	).Log(6001)
+	assert.Equal(
+		test,
+		`{"level":"PANIC","bool":true,"duration":1000000000,"float":1.5,"group":{"inner":{"int":7}},"started":"2026-01-02T03:04:05Z","uint":3,"level":"WARN","id":"6001"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_StdlibSlogHandlerOptions(test *testing.T) {
+	test.Parallel()
+
+	handlerOptions := logging.StdlibSlogHandlerOptions(nil)
+	assert.Equal(test, stdslog.Level(logging.LevelInfoInt), handlerOptions.Level.Level())
+
+	actual := handlerOptions.ReplaceAttr(nil, stdslog.Any(stdslog.LevelKey, stdslog.Level(logging.LevelTraceInt)))
+	assert.Equal(test, logging.LevelTraceName, actual.Value.String())
+
+	actual = handlerOptions.ReplaceAttr(nil, stdslog.String(stdslog.MessageKey, "A message"))
+	assert.Equal(test, "text", actual.Key)
+
+	actual = handlerOptions.ReplaceAttr(nil, stdslog.Any("valuer", logValuer{}))
+	assert.Equal(test, "resolved", actual.Value.String())
+}
+
+// ----------------------------------------------------------------------------
+// Internal types
+// ----------------------------------------------------------------------------
+
+type logValuer struct{}
+
+func (logValuer) LogValue() stdslog.Value {
+	return stdslog.StringValue("resolved")
+}
@@ -0,0 +1,621 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The syslogConfig type holds the values that are the same in every syslog message.
+type syslogConfig struct {
+	appName          string
+	facility         int
+	format           string
+	hostname         string
+	processID        string
+	structuredDataID string
+}
+
+/*
+The syslogWriter type sends each Write() as one syslog message.
+Write() only queues the message; a background goroutine connects and sends, so a slow server never blocks logging.
+The queue is bounded, dropping the oldest messages, and kept while the server cannot be reached.
+*/
+type syslogWriter struct {
+	address           string
+	bufferSize        int
+	closeErr          error
+	closed            bool
+	conn              net.Conn
+	done              chan struct{}
+	flushed           chan struct{}
+	lastDial          time.Time
+	mutex             sync.Mutex
+	network           string
+	pending           [][]byte
+	reconnectInterval time.Duration
+	sending           bool
+	stop              chan struct{}
+	tlsConfig         *tls.Config
+	wake              chan struct{}
+	writeTimeout      time.Duration
+}
+
+// --- Options for NewSyslogHandler() -----------------------------------------
+
+// The address of the syslog server, e.g. "localhost:514" or "/dev/log".
+type OptionSyslogAddress struct {
+	Value string
+}
+
+// The APP-NAME of messages.  Default: the name of the program.
+type OptionSyslogAppName struct {
+	Value string
+}
+
+// The number of messages kept while the syslog server cannot be reached.  Default: 1000.
+type OptionSyslogBufferSize struct {
+	Value int
+}
+
+// The syslog facility, 0..23.  Default: 1 (user-level messages).
+type OptionSyslogFacility struct {
+	Value int
+}
+
+// SyslogRFC5424 or SyslogRFC3164.  Default: SyslogRFC5424.
+type OptionSyslogFormat struct {
+	Value string
+}
+
+// The HOSTNAME of messages.  Default: os.Hostname().
+type OptionSyslogHostname struct {
+	Value string
+}
+
+// "udp", "tcp", "tls", "unix", or "unixgram".  Default: "unixgram" to the local syslog socket.
+type OptionSyslogNetwork struct {
+	Value string
+}
+
+// The minimum time between attempts to reconnect.  Default: 1 second.
+type OptionSyslogReconnectInterval struct {
+	Value time.Duration
+}
+
+// The SD-ID of the structured data holding message fields.  Default: "senzing@32473".
+type OptionSyslogStructuredDataID struct {
+	Value string
+}
+
+// The TLS configuration used with the "tls" network.
+type OptionSyslogTLSConfig struct {
+	Value *tls.Config
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Syslog message formats.
+const (
+	SyslogRFC3164 = "rfc3164"
+	SyslogRFC5424 = "rfc5424"
+)
+
+const (
+	syslogBufferSize        = 1000
+	syslogFacilityUser      = 1
+	syslogMaxFacility       = 23
+	syslogMaxNameLength     = 32
+	syslogNil               = "-"
+	syslogReconnectInterval = time.Second
+	syslogStructuredDataID  = "senzing@32473"
+	syslogWriteTimeout      = 5 * time.Second
+	syslogRFC3164TimeFormat = time.Stamp
+	syslogRFC5424TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Map from log level to syslog severity.
+var syslogSeverities = map[slog.Level]int{ //nolint
+	LevelTraceSlog: 7, // Debug
+	LevelDebugSlog: 7, // Debug
+	LevelInfoSlog:  6, // Informational
+	LevelWarnSlog:  4, // Warning
+	LevelErrorSlog: 3, // Error
+	LevelFatalSlog: 2, // Critical
+	LevelPanicSlog: 1, // Alert
+}
+
+// Locations of the local syslog socket.
+var syslogLocalAddresses = []string{"/dev/log", "/var/run/syslog", "/var/run/log"} //nolint
+
+// ----------------------------------------------------------------------------
+// io.WriteCloser interface methods
+// ----------------------------------------------------------------------------
+
+// Stop the background goroutine after it tries once more to send the queued messages.
+func (writer *syslogWriter) Close() error {
+	writer.mutex.Lock()
+	closed := writer.closed
+	writer.closed = true
+	writer.mutex.Unlock()
+
+	if !closed {
+		close(writer.stop)
+	}
+
+	<-writer.done
+
+	return writer.closeErr
+}
+
+// Queue one message for the background goroutine.  Messages are never refused, so Write does not fail.
+func (writer *syslogWriter) Write(data []byte) (int, error) {
+	message := writer.frame(bytes.TrimSuffix(data, []byte{'\n'}))
+
+	writer.mutex.Lock()
+
+	if !writer.closed {
+		writer.pending = append(writer.pending, message)
+		writer.trimPending()
+	}
+
+	writer.mutex.Unlock()
+	writer.notify()
+
+	return len(data), nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// Wait until the background goroutine has tried to send the queued messages.
+// Messages that could not be sent stay queued.
+func (writer *syslogWriter) Flush(ctx context.Context) error {
+	writer.mutex.Lock()
+
+	if writer.closed || (len(writer.pending) == 0 && !writer.sending) {
+		writer.mutex.Unlock()
+
+		return nil
+	}
+
+	if writer.flushed == nil {
+		writer.flushed = make(chan struct{})
+	}
+
+	flushed := writer.flushed
+	writer.mutex.Unlock()
+	writer.notify()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return wraperror.Errorf(ctx.Err(), "Flush")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewSyslogHandler function creates an slog.Handler that sends records to a syslog server.
+Use it with OptionHandler or Sink.Handler.
+
+The log level becomes the syslog severity:
+TRACE and DEBUG are 7 (debug), INFO is 6, WARN is 4, ERROR is 3, FATAL is 2 (critical), and PANIC is 1 (alert).
+The message id (e.g. "SZTL99992001") becomes the MSGID and the message text becomes the MSG.
+In RFC 5424 format, the other fields become the structured data.
+In RFC 3164 format, the other fields are appended to the MSG as key=value pairs.
+
+Input
+  - options: OptionSyslogXxxx values and OptionTimeHidden.
+
+Output
+  - An slog.Handler
+  - error
+*/
+func NewSyslogHandler(options ...interface{}) (slog.Handler, error) {
+	writer := &syslogWriter{
+		bufferSize:        syslogBufferSize,
+		done:              make(chan struct{}),
+		reconnectInterval: syslogReconnectInterval,
+		stop:              make(chan struct{}),
+		wake:              make(chan struct{}, 1),
+		writeTimeout:      syslogWriteTimeout,
+	}
+	config := &syslogConfig{
+		appName:          filepath.Base(os.Args[0]),
+		facility:         syslogFacilityUser,
+		format:           SyslogRFC5424,
+		processID:        strconv.Itoa(os.Getpid()),
+		structuredDataID: syslogStructuredDataID,
+	}
+
+	config.hostname, _ = os.Hostname()
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionSyslogAddress:
+			writer.address = typedValue.Value
+		case OptionSyslogAppName:
+			config.appName = typedValue.Value
+		case OptionSyslogBufferSize:
+			writer.bufferSize = typedValue.Value
+		case OptionSyslogFacility:
+			config.facility = typedValue.Value
+		case OptionSyslogFormat:
+			config.format = typedValue.Value
+		case OptionSyslogHostname:
+			config.hostname = typedValue.Value
+		case OptionSyslogNetwork:
+			writer.network = typedValue.Value
+		case OptionSyslogReconnectInterval:
+			writer.reconnectInterval = typedValue.Value
+		case OptionSyslogStructuredDataID:
+			config.structuredDataID = typedValue.Value
+		case OptionSyslogTLSConfig:
+			writer.tlsConfig = typedValue.Value
+		}
+	}
+
+	err := verifySyslog(config, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	go writer.run()
+
+	handlerOptions := SlogHandlerOptions(LevelTraceSlog, options...)
+
+	return newEncodingHandler(writer, handlerOptions, syslogEncoder(config)), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (writer *syslogWriter) dial() (net.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	dialer := &net.Dialer{Timeout: writer.writeTimeout}
+
+	switch writer.network {
+	case "tls":
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: writer.tlsConfig}
+		conn, err = tlsDialer.Dial("tcp", writer.address)
+	case "":
+		for _, address := range syslogLocalAddresses {
+			conn, err = dialer.Dial("unixgram", address)
+			if err == nil {
+				break
+			}
+		}
+	default:
+		conn, err = dialer.Dial(writer.network, writer.address)
+	}
+
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Dial")
+	}
+
+	return conn, nil
+}
+
+// Wake the background goroutine.  It is woken once for any number of calls made while it is busy.
+func (writer *syslogWriter) notify() {
+	select {
+	case writer.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stream connections use octet counting framing (RFC 6587).  Datagrams hold exactly one message.
+func (writer *syslogWriter) frame(message []byte) []byte {
+	switch writer.network {
+	case "tcp", "tls", "unix":
+		return append([]byte(strconv.Itoa(len(message))+" "), message...)
+	default:
+		return bytes.Clone(message)
+	}
+}
+
+/*
+The background goroutine.  It sends queued messages when woken by Write() or Flush(),
+and, after a failure, again when the reconnect interval has passed.
+It alone uses the connection, so the mutex is never held during network I/O.
+*/
+func (writer *syslogWriter) run() {
+	defer close(writer.done)
+
+	var retry <-chan time.Time
+
+	for {
+		select {
+		case <-writer.wake:
+		case <-retry:
+		case <-writer.stop:
+			writer.send()
+
+			if writer.conn != nil {
+				err := writer.conn.Close()
+				if err != nil {
+					writer.closeErr = wraperror.Errorf(err, "Close")
+				}
+			}
+
+			return
+		}
+
+		retry = nil
+
+		if wait := writer.send(); wait > 0 {
+			retry = time.After(wait)
+		}
+	}
+}
+
+/*
+Send queued messages, connecting first if needed, at most once per reconnect interval.
+If a message cannot be sent, it stays queued and the connection is dropped.
+Returns the time to wait before trying again, or 0 to wait for the next Write().
+*/
+func (writer *syslogWriter) send() time.Duration {
+	defer writer.signalFlushed()
+
+	for {
+		if writer.conn == nil {
+			if wait := writer.reconnectInterval - time.Since(writer.lastDial); wait > 0 {
+				return wait
+			}
+
+			writer.lastDial = time.Now()
+
+			conn, err := writer.dial()
+			if err != nil {
+				return writer.reconnectInterval
+			}
+
+			writer.conn = conn
+		}
+
+		writer.mutex.Lock()
+
+		if len(writer.pending) == 0 {
+			writer.mutex.Unlock()
+
+			return 0
+		}
+
+		message := writer.pending[0]
+		writer.pending = writer.pending[1:]
+		writer.sending = true
+		writer.mutex.Unlock()
+
+		_ = writer.conn.SetWriteDeadline(time.Now().Add(writer.writeTimeout))
+		_, err := writer.conn.Write(message)
+
+		writer.mutex.Lock()
+		writer.sending = false
+
+		if err != nil {
+			writer.pending = append([][]byte{message}, writer.pending...)
+			writer.trimPending()
+		}
+
+		writer.mutex.Unlock()
+
+		if err != nil {
+			_ = writer.conn.Close()
+			writer.conn = nil
+
+			return writer.reconnectInterval
+		}
+	}
+}
+
+// Release the callers of Flush() waiting for the end of a send.
+func (writer *syslogWriter) signalFlushed() {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	if writer.flushed != nil {
+		close(writer.flushed)
+		writer.flushed = nil
+	}
+}
+
+// Drop the oldest messages beyond the buffer size.  The caller holds the mutex.
+func (writer *syslogWriter) trimPending() {
+	if len(writer.pending) > writer.bufferSize {
+		writer.pending = writer.pending[len(writer.pending)-writer.bufferSize:]
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Render fields as an RFC 5424 or RFC 3164 syslog message.
+func syslogEncoder(config *syslogConfig) formatEncoder {
+	return func(buffer *bytes.Buffer, level slog.Level, fields []slog.Attr) {
+		var (
+			idValue   string
+			remaining = make([]slog.Attr, 0, len(fields))
+			text      string
+			timeValue = time.Now()
+		)
+
+		for _, field := range fields {
+			switch field.Key {
+			case slog.TimeKey:
+				if field.Value.Kind() == slog.KindTime {
+					timeValue = field.Value.Time()
+				}
+			case slog.LevelKey:
+			case "id":
+				idValue = field.Value.String()
+			case "text", slog.MessageKey:
+				text = field.Value.String()
+			default:
+				remaining = append(remaining, detailFields(field)...)
+			}
+		}
+
+		priority := config.facility*8 + syslogSeverity(level) //nolint:mnd
+
+		if config.format == SyslogRFC3164 {
+			fmt.Fprintf(buffer, "<%d>%s %s %s[%s]: ",
+				priority, timeValue.Format(syslogRFC3164TimeFormat), syslogName(config.hostname, 255), //nolint:mnd
+				syslogName(config.appName, syslogMaxNameLength), config.processID)
+
+			parts := []string{}
+			if idValue != "" {
+				parts = append(parts, idValue+":")
+			}
+
+			if text != "" {
+				parts = append(parts, text)
+			}
+
+			for _, field := range remaining {
+				parts = append(parts, logfmtKey(field.Key)+"="+logfmtQuote(valueAsString(field.Value)))
+			}
+
+			buffer.WriteString(strings.Join(parts, " "))
+
+			return
+		}
+
+		fmt.Fprintf(buffer, "<%d>1 %s %s %s %s %s ",
+			priority, timeValue.Format(syslogRFC5424TimeFormat), syslogName(config.hostname, 255), //nolint:mnd
+			syslogName(config.appName, 48), syslogName(config.processID, 128), //nolint:mnd
+			syslogName(idValue, syslogMaxNameLength))
+
+		if len(remaining) == 0 {
+			buffer.WriteString(syslogNil)
+		} else {
+			buffer.WriteString("[" + config.structuredDataID)
+
+			for _, field := range remaining {
+				buffer.WriteString(" " + syslogParamName(field.Key) + `="` + syslogParamValue(valueAsString(field.Value)) + `"`)
+			}
+
+			buffer.WriteString("]")
+		}
+
+		if text != "" {
+			buffer.WriteString(" " + text)
+		}
+	}
+}
+
+// Header fields are printable US-ASCII without spaces.  Empty fields are "-".
+func syslogName(value string, maxLength int) string {
+	result := strings.Map(func(character rune) rune {
+		if character <= ' ' || character > '~' {
+			return -1
+		}
+
+		return character
+	}, value)
+
+	if len(result) > maxLength {
+		result = result[:maxLength]
+	}
+
+	if result == "" {
+		return syslogNil
+	}
+
+	return result
+}
+
+// SD-PARAM names may not contain '=', ' ', ']', or '"'.
+func syslogParamName(key string) string {
+	result := strings.Map(func(character rune) rune {
+		if character == '=' || character == ']' || character == '"' || character <= ' ' || character > '~' {
+			return '_'
+		}
+
+		return character
+	}, key)
+
+	if len(result) > syslogMaxNameLength {
+		result = result[:syslogMaxNameLength]
+	}
+
+	return result
+}
+
+// SD-PARAM values escape '"', '\', and ']'.
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// The syslog severity of the closest level at or below the log level.
+func syslogSeverity(level slog.Level) int {
+	result := syslogSeverities[LevelTraceSlog]
+	closest := LevelTraceSlog
+
+	for slogLevel, severity := range syslogSeverities {
+		if slogLevel <= level && slogLevel >= closest {
+			closest = slogLevel
+			result = severity
+		}
+	}
+
+	return result
+}
+
+func verifySyslog(config *syslogConfig, writer *syslogWriter) error {
+	if config.format != SyslogRFC5424 && config.format != SyslogRFC3164 {
+		return wraperror.Errorf(errForPackage, "unknown syslog format: %s", config.format)
+	}
+
+	if config.facility < 0 || config.facility > syslogMaxFacility {
+		return wraperror.Errorf(errForPackage, "syslog facility %d must be in range 0..23", config.facility)
+	}
+
+	if writer.bufferSize <= 0 {
+		return wraperror.Errorf(errForPackage, "syslog buffer size %d must be positive", writer.bufferSize)
+	}
+
+	switch writer.network {
+	case "":
+	case "tcp", "tls", "udp", "unix", "unixgram":
+		if writer.address == "" {
+			return wraperror.Errorf(errForPackage, "no syslog address for network: %s", writer.network)
+		}
+	default:
+		return wraperror.Errorf(errForPackage, "unknown syslog network: %s", writer.network)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package logging_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_NewSyslogHandler_udp(test *testing.T) {
+	test.Parallel()
+
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(test, err)
+
+	defer packetConn.Close()
+
+	handler, err := logging.NewSyslogHandler(
+		logging.OptionSyslogNetwork{Value: "udp"},
+		logging.OptionSyslogAddress{Value: packetConn.LocalAddr().String()},
+		logging.OptionSyslogAppName{Value: "test-app"},
+		logging.OptionSyslogHostname{Value: "test-host"},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesTest,
+		logging.OptionHandler{Value: handler},
+		logging.OptionMessageFields{Value: []string{"id", "text", "details"}},
+	)
+	require.NoError(test, err)
+	logger.Log(4001, "Bob", "Jane")
+
+	buffer := make([]byte, 2048) //nolint:mnd
+	_ = packetConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	length, _, err := packetConn.ReadFrom(buffer)
+	require.NoError(test, err)
+	assert.Regexp(
+		test,
+		`^<11>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}Z test-host test-app \d+ SZTL99974001 `+
+			`\[senzing@32473 details.1="Bob" details.2="Jane"\] ERROR: Bob works with Jane$`,
+		string(buffer[:length]),
+	)
+}
+
+func TestLogging_NewSyslogHandler_tcp(test *testing.T) {
+	test.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+
+	defer listener.Close()
+
+	messages := receiveSyslog(test, listener)
+	handler, err := logging.NewSyslogHandler(
+		logging.OptionSyslogNetwork{Value: "tcp"},
+		logging.OptionSyslogAddress{Value: listener.Addr().String()},
+		logging.OptionSyslogAppName{Value: "test-app"},
+		logging.OptionSyslogHostname{Value: "test-host"},
+		logging.OptionSyslogFacility{Value: 16},
+		logging.OptionSyslogStructuredDataID{Value: "example@32473"},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		logging.OptionHandler{Value: handler},
+		logging.OptionLogLevel{Value: logging.LevelTraceName},
+		logging.OptionMessageFields{Value: []string{"id", "text", "details"}},
+	)
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).With("path", `/a "b"`).Log(1, "Bob", "Jane")
+	logger.Log(6001, "Bob", "Jane")
+
+	assert.Regexp(
+		test,
+		`^<135>1 \S+ test-host test-app \d+ 1 \[example@32473 path="/a \\"b\\"" details.1="Bob" details.2="Jane"\] TRACE: Bob works with Jane$`,
+		nextMessage(test, messages),
+	)
+	assert.Regexp(
+		test,
+		`^<129>1 \S+ test-host test-app \d+ 6001 \[example@32473 details.1="Bob" details.2="Jane"\] PANIC: Bob works with Jane$`,
+		nextMessage(test, messages),
+	)
+}
+
+func TestLogging_NewSyslogHandler_rfc3164(test *testing.T) {
+	test.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+
+	defer listener.Close()
+
+	messages := receiveSyslog(test, listener)
+	handler, err := logging.NewSyslogHandler(
+		logging.OptionSyslogNetwork{Value: "tcp"},
+		logging.OptionSyslogAddress{Value: listener.Addr().String()},
+		logging.OptionSyslogAppName{Value: "test-app"},
+		logging.OptionSyslogHostname{Value: "test-host"},
+		logging.OptionSyslogFormat{Value: logging.SyslogRFC3164},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).With("job", "job-20").Log(3001, logging.MessageText{Value: "A warning"})
+
+	assert.Regexp(
+		test,
+		`^<12>\w{3} [ \d]\d \d\d:\d\d:\d\d test-host test-app\[\d+\]: 3001: A warning job=job-20$`,
+		nextMessage(test, messages),
+	)
+}
+
+func TestLogging_NewSyslogHandler_reconnect(test *testing.T) {
+	test.Parallel()
+
+	address := filepath.Join(test.TempDir(), "syslog.sock")
+	listener, err := net.Listen("unix", address)
+	require.NoError(test, err)
+
+	handler, err := logging.NewSyslogHandler(
+		logging.OptionSyslogNetwork{Value: "unix"},
+		logging.OptionSyslogAddress{Value: address},
+		logging.OptionSyslogBufferSize{Value: 3},
+		logging.OptionSyslogReconnectInterval{Value: 0},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+
+	// Receive one message, then stop the server.
+
+	connections, messages := acceptSyslog(test, listener)
+	logger.Log(2001, logging.MessageText{Value: "message-1"})
+	assert.Contains(test, nextMessage(test, messages), "message-1")
+	require.NoError(test, (<-connections).Close())
+	require.NoError(test, listener.Close())
+
+	// While the server is down, only the last 3 messages are kept.
+
+	for _, text := range []string{"message-2", "message-3", "message-4"} {
+		logger.Log(2001, logging.MessageText{Value: text})
+	}
+
+	listener, err = net.Listen("unix", address)
+	require.NoError(test, err)
+
+	defer listener.Close()
+
+	_, messages = acceptSyslog(test, listener)
+	logger.Log(2001, logging.MessageText{Value: "message-5"})
+	assert.Contains(test, nextMessage(test, messages), "message-3")
+	assert.Contains(test, nextMessage(test, messages), "message-4")
+	assert.Contains(test, nextMessage(test, messages), "message-5")
+}
+
+func TestLogging_NewSyslogHandler_slowServer(test *testing.T) {
+	test.Parallel()
+
+	address := filepath.Join(test.TempDir(), "syslog.sock")
+	listener, err := net.Listen("unix", address)
+	require.NoError(test, err)
+
+	defer listener.Close()
+
+	handler, err := logging.NewSyslogHandler(
+		logging.OptionSyslogNetwork{Value: "unix"},
+		logging.OptionSyslogAddress{Value: address},
+		logging.OptionSyslogBufferSize{Value: 10},
+	)
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+
+	// The server accepts the connection, but never reads, so the socket buffer fills.
+
+	connections := acceptSlowSyslog(test, listener)
+	text := strings.Repeat("x", 64*1024) //nolint:mnd
+	start := time.Now()
+
+	for range 100 {
+		logger.Log(2001, logging.MessageText{Value: text})
+	}
+
+	assert.Less(test, time.Since(start), 2*time.Second)
+
+	select {
+	case conn := <-connections:
+		require.NoError(test, conn.Close())
+	case <-time.After(5 * time.Second):
+		test.Fatal("no syslog connection")
+	}
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+}
+
+func TestLogging_NewSyslogHandler_badOptions(test *testing.T) {
+	test.Parallel()
+
+	badOptions := [][]interface{}{
+		{logging.OptionSyslogFormat{Value: "rfc0"}},
+		{logging.OptionSyslogFacility{Value: 24}},
+		{logging.OptionSyslogBufferSize{Value: 0}},
+		{logging.OptionSyslogNetwork{Value: "tcp"}},
+		{logging.OptionSyslogNetwork{Value: "carrier-pigeon"}, logging.OptionSyslogAddress{Value: "roof"}},
+	}
+
+	for _, options := range badOptions {
+		_, err := logging.NewSyslogHandler(options...)
+		require.Error(test, err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Accept one connection and read octet-counted messages from it.
+func acceptSyslog(test *testing.T, listener net.Listener) (chan net.Conn, chan string) {
+	test.Helper()
+
+	connections := make(chan net.Conn, 1)
+	messages := make(chan string, 10) //nolint:mnd
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(messages)
+
+			return
+		}
+
+		connections <- conn
+
+		reader := bufio.NewReader(conn)
+
+		for {
+			length, err := reader.ReadString(' ')
+			if err != nil {
+				close(messages)
+
+				return
+			}
+
+			size, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				close(messages)
+
+				return
+			}
+
+			message := make([]byte, size)
+
+			_, err = io.ReadFull(reader, message)
+			if err != nil {
+				close(messages)
+
+				return
+			}
+
+			messages <- string(message)
+		}
+	}()
+
+	return connections, messages
+}
+
+// Accept one connection without reading from it.
+func acceptSlowSyslog(test *testing.T, listener net.Listener) chan net.Conn {
+	test.Helper()
+
+	connections := make(chan net.Conn, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			connections <- conn
+		}
+	}()
+
+	return connections
+}
+
+func nextMessage(test *testing.T, messages chan string) string {
+	test.Helper()
+
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(5 * time.Second):
+		test.Fatal("no syslog message received")
+
+		return ""
+	}
+}
+
+func receiveSyslog(test *testing.T, listener net.Listener) chan string {
+	test.Helper()
+
+	_, messages := acceptSyslog(test, listener)
+
+	return messages
+}
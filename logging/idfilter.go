@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync/atomic"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The forcedKey type is the context key marking records that an IDFilter enabled.
+type forcedKey struct{}
+
+// The idFilters type holds the IDFilters of a logger.  They may be replaced while messages are logged.
+type idFilters struct {
+	filters atomic.Pointer[[]IDFilter]
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The narrowest IDFilter whose range includes the message number.
+func (filters *idFilters) find(messageNumber int) (IDFilter, bool) {
+	current := filters.filters.Load()
+	if current == nil {
+		return IDFilter{}, false
+	}
+
+	for _, filter := range *current {
+		if messageNumber >= filter.FirstMessageNumber && messageNumber <= filter.LastMessageNumber {
+			return filter, true
+		}
+	}
+
+	return IDFilter{}, false
+}
+
+// Replace the IDFilters, narrowest range first.
+func (filters *idFilters) set(newFilters []IDFilter) error {
+	err := verifyIDFilters(newFilters)
+	if err != nil {
+		return err
+	}
+
+	sorted := slices.Clone(newFilters)
+	slices.SortStableFunc(sorted, func(filter1 IDFilter, filter2 IDFilter) int {
+		return cmp.Compare(
+			filter1.LastMessageNumber-filter1.FirstMessageNumber,
+			filter2.LastMessageNumber-filter2.FirstMessageNumber,
+		)
+	})
+	filters.filters.Store(&sorted)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Returns true if an IDFilter enabled the record, so it is logged regardless of log levels.
+func isForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forcedKey{}).(bool)
+
+	return forced
+}
+
+// A context marking the record as enabled by an IDFilter.
+func withForced(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcedKey{}, true)
+}
+
+func verifyIDFilters(filters []IDFilter) error {
+	for _, filter := range filters {
+		if filter.FirstMessageNumber > filter.LastMessageNumber {
+			return wraperror.Errorf(
+				errForPackage,
+				"ID filter message numbers %d..%d are not in order",
+				filter.FirstMessageNumber,
+				filter.LastMessageNumber,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionIDFilters(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		getOptionLogLevel(logging.LevelWarnName),
+		optionOutput(outputString),
+		logging.OptionIDFilters{Value: []logging.IDFilter{
+			{Enabled: true, FirstMessageNumber: 2100, LastMessageNumber: 2199},
+			{Enabled: false, FirstMessageNumber: 2150, LastMessageNumber: 2150},
+		}},
+		logging.OptionIDFilter{Value: logging.IDFilter{FirstMessageNumber: 3005, LastMessageNumber: 3005}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	logger.Log(2101)
+	logger.Log(2150)
+	logger.Log(3001)
+	logger.Log(3005)
+	assert.Equal(test, `{"level":"INFO","id":"2101"}`+"\n"+`{"level":"WARN","id":"3001"}`+"\n", outputString.String())
+
+	filterLogger := logger.(logging.FilterLogging)
+	assert.False(test, filterLogger.IsEnabled(2001))
+	assert.True(test, filterLogger.IsEnabled(2101))
+	assert.False(test, filterLogger.IsEnabled(2150))
+	assert.True(test, filterLogger.IsEnabled(3001))
+	assert.False(test, filterLogger.IsEnabled(3005))
+	assert.True(test, filterLogger.IsEnabled(6001))
+}
+
+func TestBasicLogging_SetIDFilters(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(getOptionTimeHidden(), optionOutput(outputString))
+	require.NoError(test, err)
+
+	filterLogger := logger.(logging.FilterLogging)
+	withLogger := logger.(logging.AttrLogging).With("jobID", "job-20")
+	require.NoError(test, filterLogger.SetIDFilters([]logging.IDFilter{
+		{Enabled: true, FirstMessageNumber: 1000, LastMessageNumber: 1999},
+		{Enabled: false, FirstMessageNumber: 2001, LastMessageNumber: 2001},
+	}))
+	withLogger.Log(1001)
+	withLogger.Log(2001)
+	assert.Equal(test, `{"level":"DEBUG","jobID":"job-20","id":"1001"}`+"\n", outputString.String())
+
+	require.NoError(test, filterLogger.SetIDFilters(nil))
+	withLogger.Log(1001)
+	withLogger.Log(2001)
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"id":"2001"`))
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"id":"1001"`))
+
+	require.Error(test, filterLogger.SetIDFilters([]logging.IDFilter{{FirstMessageNumber: 2, LastMessageNumber: 1}}))
+}
+
+func TestLogging_New_optionIDFilters_sinks(test *testing.T) {
+	test.Parallel()
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionIDFilter{Value: logging.IDFilter{Enabled: true, FirstMessageNumber: 1, LastMessageNumber: 1}},
+		logging.OptionSinks{Value: []logging.Sink{
+			{Name: "console", Output: consoleString, LogLevel: logging.LevelErrorName},
+			{Name: "file", Output: fileString},
+		}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(1)
+	logger.Log(2)
+	assert.Equal(test, `{"level":"TRACE","id":"1"}`+"\n", consoleString.String())
+	assert.Equal(test, `{"level":"TRACE","id":"1"}`+"\n", fileString.String())
+}
+
+func TestLogging_New_optionIDFilters_bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(
+		logging.OptionIDFilter{Value: logging.IDFilter{FirstMessageNumber: 3999, LastMessageNumber: 3000}},
+	)
+	require.Error(test, err)
+}
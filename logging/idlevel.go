@@ -0,0 +1,281 @@
+package logging
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The idLevelRanges type maps message numbers to levels, by OptionIDLevelRanges or IDLevelRangesAsString.
+// Levels of single message numbers, from a Catalog, take precedence over ranges.
+type idLevelRanges struct {
+	levels           map[int]string
+	outOfRangeLevel  string
+	outOfRangePolicy string
+	ranges           []IDLevelRange
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The last message number of the ranges built from IDLevelRangesAsString.
+const defaultLastMessageNumber = 6999
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Add the level of the message number to the details, unless the details have a MessageLevel.
+// A nil idLevelRanges, or a level go-messaging would choose itself, leaves the level to go-messaging.
+func (ranges *idLevelRanges) appendLevel(messageNumber int, details []interface{}) []interface{} {
+	if ranges == nil {
+		return details
+	}
+
+	for _, detail := range details {
+		if _, ok := detail.(messenger.MessageLevel); ok {
+			return details
+		}
+	}
+
+	levelName, _ := ranges.levelName(messageNumber)
+	if levelName == defaultLevelName(messageNumber) {
+		return details
+	}
+
+	return append(details, messenger.MessageLevel{Value: levelName})
+}
+
+// Returns true if the message number is outside all ranges and IDOutOfRangeDrop is used.
+func (ranges *idLevelRanges) drops(messageNumber int) bool {
+	if ranges == nil {
+		return false
+	}
+
+	_, ok := ranges.levelName(messageNumber)
+
+	return !ok
+}
+
+// The name of the level of a message number.  False if the message is dropped.
+func (ranges *idLevelRanges) levelName(messageNumber int) (string, bool) {
+	if ranges == nil {
+		return defaultLevelName(messageNumber), true
+	}
+
+	if levelName, ok := ranges.levels[messageNumber]; ok {
+		return levelName, true
+	}
+
+	if len(ranges.ranges) == 0 {
+		return defaultLevelName(messageNumber), true
+	}
+
+	index, found := slices.BinarySearchFunc(ranges.ranges, messageNumber, func(idLevelRange IDLevelRange, target int) int {
+		switch {
+		case target < idLevelRange.FirstMessageNumber:
+			return 1
+		case target > idLevelRange.LastMessageNumber:
+			return -1
+		default:
+			return 0
+		}
+	})
+	if found {
+		return ranges.ranges[index].LogLevel, true
+	}
+
+	if ranges.outOfRangePolicy == IDOutOfRangeDrop {
+		return "", false
+	}
+
+	return ranges.outOfRangeLevel, true
+}
+
+// The lowest message number of each level, among the first message numbers of ranges and the catalog levels.
+// A nil idLevelRanges uses IDLevelRangesAsString.
+func (ranges *idLevelRanges) lowestMessageNumbers() map[string]int {
+	var candidates []int
+
+	if ranges == nil || len(ranges.ranges) == 0 {
+		candidates = slices.Collect(maps.Keys(IDLevelRangesAsString))
+	} else {
+		for _, idLevelRange := range ranges.ranges {
+			candidates = append(candidates, idLevelRange.FirstMessageNumber)
+		}
+	}
+
+	if ranges != nil {
+		candidates = append(candidates, slices.Collect(maps.Keys(ranges.levels))...)
+	}
+
+	slices.Sort(candidates)
+
+	result := map[string]int{}
+
+	for _, messageNumber := range slices.Compact(candidates) {
+		levelName, ok := ranges.levelName(messageNumber)
+		if _, found := result[levelName]; ok && !found {
+			result[levelName] = messageNumber
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The level of a message number according to IDLevelRangesAsString, as go-messaging determines it.
+func defaultLevelName(messageNumber int) string {
+	result := LevelPanicName
+	lowBound := -1
+
+	for rangeLowBound, levelName := range IDLevelRangesAsString {
+		if rangeLowBound <= messageNumber && rangeLowBound > lowBound {
+			lowBound = rangeLowBound
+			result = levelName
+		}
+	}
+
+	return result
+}
+
+// The ranges of IDLevelRangesAsString, from 0 to defaultLastMessageNumber.
+func defaultIDLevelRanges() []IDLevelRange {
+	firstMessageNumbers := slices.Sorted(maps.Keys(IDLevelRangesAsString))
+	result := make([]IDLevelRange, 0, len(firstMessageNumbers))
+
+	for index, firstMessageNumber := range firstMessageNumbers {
+		lastMessageNumber := defaultLastMessageNumber
+		if index+1 < len(firstMessageNumbers) {
+			lastMessageNumber = firstMessageNumbers[index+1] - 1
+		}
+
+		result = append(result, IDLevelRange{
+			FirstMessageNumber: firstMessageNumber,
+			LastMessageNumber:  lastMessageNumber,
+			LogLevel:           IDLevelRangesAsString[firstMessageNumber],
+		})
+	}
+
+	return result
+}
+
+// The ranges in order.  Without OptionIDLevelRanges, the ranges are those of IDLevelRangesAsString
+// and message numbers above them are PANIC by default, as go-messaging logs them.
+func newIDLevelRanges(extractedValues *ExtractedValues) *idLevelRanges {
+	ranges := extractedValues.idLevelRanges
+	outOfRangeLevel := extractedValues.idOutOfRangeLevel
+
+	switch {
+	case len(ranges) == 0:
+		ranges = defaultIDLevelRanges()
+
+		if outOfRangeLevel == "" {
+			outOfRangeLevel = LevelPanicName
+		}
+	case outOfRangeLevel == "":
+		outOfRangeLevel = LevelErrorName
+	}
+
+	return &idLevelRanges{
+		levels:           extractedValues.idLevels,
+		outOfRangeLevel:  outOfRangeLevel,
+		outOfRangePolicy: extractedValues.idOutOfRangePolicy,
+		ranges:           sortedIDLevelRanges(ranges),
+	}
+}
+
+func sortedIDLevelRanges(ranges []IDLevelRange) []IDLevelRange {
+	result := slices.Clone(ranges)
+	slices.SortFunc(result, func(range1 IDLevelRange, range2 IDLevelRange) int {
+		return cmp.Compare(range1.FirstMessageNumber, range2.FirstMessageNumber)
+	})
+
+	return result
+}
+
+// Levels of single message numbers must be known level names.
+func verifyCatalogLevels(idLevels map[int]string) error {
+	for _, messageNumber := range slices.Sorted(maps.Keys(idLevels)) {
+		if !IsValidLogLevelName(idLevels[messageNumber]) {
+			return wraperror.Errorf(
+				errForPackage,
+				"catalog message %d has unknown level: %s",
+				messageNumber,
+				idLevels[messageNumber],
+			)
+		}
+	}
+
+	return nil
+}
+
+// Ranges must be valid, and must not overlap or leave gaps between them.
+func verifyIDLevelRanges(extractedValues *ExtractedValues) error {
+	switch extractedValues.idOutOfRangePolicy {
+	case IDOutOfRangeDrop, IDOutOfRangeLevel:
+	default:
+		return wraperror.Errorf(errForPackage, "unknown ID out of range policy: %s", extractedValues.idOutOfRangePolicy)
+	}
+
+	if extractedValues.idOutOfRangeLevel != "" && !IsValidLogLevelName(extractedValues.idOutOfRangeLevel) {
+		return wraperror.Errorf(errForPackage, "unknown error level: %s", extractedValues.idOutOfRangeLevel)
+	}
+
+	ranges := sortedIDLevelRanges(extractedValues.idLevelRanges)
+
+	for index, idLevelRange := range ranges {
+		if idLevelRange.FirstMessageNumber > idLevelRange.LastMessageNumber {
+			return wraperror.Errorf(
+				errForPackage,
+				"ID level range %d..%d is not in order",
+				idLevelRange.FirstMessageNumber,
+				idLevelRange.LastMessageNumber,
+			)
+		}
+
+		if !IsValidLogLevelName(idLevelRange.LogLevel) {
+			return wraperror.Errorf(errForPackage, "unknown error level: %s", idLevelRange.LogLevel)
+		}
+
+		if index == 0 {
+			continue
+		}
+
+		previous := ranges[index-1]
+
+		switch {
+		case idLevelRange.FirstMessageNumber <= previous.LastMessageNumber:
+			return wraperror.Errorf(
+				errForPackage,
+				"ID level ranges %d..%d and %d..%d overlap",
+				previous.FirstMessageNumber,
+				previous.LastMessageNumber,
+				idLevelRange.FirstMessageNumber,
+				idLevelRange.LastMessageNumber,
+			)
+		case idLevelRange.FirstMessageNumber > previous.LastMessageNumber+1:
+			return wraperror.Errorf(
+				errForPackage,
+				"gap between ID level ranges %d..%d and %d..%d",
+				previous.FirstMessageNumber,
+				previous.LastMessageNumber,
+				idLevelRange.FirstMessageNumber,
+				idLevelRange.LastMessageNumber,
+			)
+		}
+	}
+
+	return nil
+}
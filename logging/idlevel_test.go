@@ -0,0 +1,167 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getOptionIDLevelRanges() logging.OptionIDLevelRanges {
+	return logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+		{FirstMessageNumber: 10000, LastMessageNumber: 19999, LogLevel: logging.LevelDebugName},
+		{FirstMessageNumber: 20000, LastMessageNumber: 29999, LogLevel: logging.LevelInfoName},
+		{FirstMessageNumber: 30000, LastMessageNumber: 39999, LogLevel: logging.LevelWarnName},
+	}}
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionIDLevelRanges(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDLevelRanges(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+	)
+	require.NoError(test, err)
+
+	logger.Log(10001)
+	logger.Log(20001)
+	logger.Log(30001)
+	logger.Log(7001)
+	assert.Equal(
+		test,
+		`{"level":"INFO","id":"20001"}`+"\n"+
+			`{"level":"WARN","id":"30001"}`+"\n"+
+			`{"level":"ERROR","id":"7001"}`+"\n",
+		outputString.String(),
+	)
+	assert.False(test, logger.(logging.FilterLogging).IsEnabled(10001))
+	assert.True(test, logger.(logging.FilterLogging).IsEnabled(20001))
+}
+
+func TestLogging_New_optionIDOutOfRangePolicy(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDLevelRanges(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionIDOutOfRangePolicy{Value: logging.IDOutOfRangeDrop},
+	)
+	require.NoError(test, err)
+
+	logger.Log(7001)
+	logger.Log(40000)
+	assert.Empty(test, outputString.String())
+	assert.False(test, logger.(logging.FilterLogging).IsEnabled(7001))
+
+	logger, err = logging.New(
+		getOptionIDLevelRanges(),
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionIDOutOfRangeLevel{Value: logging.LevelWarnName},
+	)
+	require.NoError(test, err)
+	logger.Log(7001)
+	assert.Equal(test, `{"level":"WARN","id":"7001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionIDOutOfRangePolicy_defaultRanges(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionIDOutOfRangePolicy{Value: logging.IDOutOfRangeDrop},
+	)
+	require.NoError(test, err)
+
+	logger.Log(6999)
+	logger.Log(7001)
+	assert.Equal(test, `{"level":"PANIC","id":"6999"}`+"\n", outputString.String())
+	assert.False(test, logger.(logging.FilterLogging).IsEnabled(7001))
+
+	outputString.Reset()
+	logger, err = logging.New(getOptionTimeHidden(), optionOutput(outputString))
+	require.NoError(test, err)
+	logger.Log(7001)
+	assert.Equal(test, `{"level":"PANIC","id":"7001"}`+"\n", outputString.String())
+
+	outputString.Reset()
+	logger, err = logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionIDOutOfRangeLevel{Value: logging.LevelWarnName},
+	)
+	require.NoError(test, err)
+	logger.Log(2001)
+	logger.Log(7001)
+	assert.Equal(test, `{"level":"INFO","id":"2001"}`+"\n"+`{"level":"WARN","id":"7001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionIDLevelRanges_bad(test *testing.T) {
+	test.Parallel()
+
+	testCases := []struct {
+		name    string
+		options []interface{}
+	}{
+		{
+			name: "overlap",
+			options: []interface{}{logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+				{FirstMessageNumber: 0, LastMessageNumber: 1999, LogLevel: logging.LevelInfoName},
+				{FirstMessageNumber: 1000, LastMessageNumber: 2999, LogLevel: logging.LevelWarnName},
+			}}},
+		},
+		{
+			name: "gap",
+			options: []interface{}{logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+				{FirstMessageNumber: 0, LastMessageNumber: 999, LogLevel: logging.LevelInfoName},
+				{FirstMessageNumber: 2000, LastMessageNumber: 2999, LogLevel: logging.LevelWarnName},
+			}}},
+		},
+		{
+			name: "order",
+			options: []interface{}{logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+				{FirstMessageNumber: 999, LastMessageNumber: 0, LogLevel: logging.LevelInfoName},
+			}}},
+		},
+		{
+			name: "level",
+			options: []interface{}{logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+				{FirstMessageNumber: 0, LastMessageNumber: 999, LogLevel: badLogLevelName},
+			}}},
+		},
+		{
+			name:    "policy",
+			options: []interface{}{logging.OptionIDOutOfRangePolicy{Value: "ignore"}},
+		},
+		{
+			name:    "outOfRangeLevel",
+			options: []interface{}{logging.OptionIDOutOfRangeLevel{Value: badLogLevelName}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			_, err := logging.New(testCase.options...)
+			require.Error(test, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"slices"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Levels registered with CustomLevel.Always.
+var alwaysLevels = map[slog.Level]bool{} //nolint
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Returns true if records at the level are logged regardless of log levels.
+func isAlwaysLevel(level slog.Level) bool {
+	return alwaysLevels[level]
+}
+
+// The name of a level, e.g. "INFO", or "INFO+2" for levels between the named levels.
+func levelName(level slog.Level) string {
+	result, ok := LevelToTextMap[level]
+	if !ok {
+		result = level.String()
+	}
+
+	return result
+}
+
+// The name of a level written by slog as a string, e.g. "DEBUG-4" becomes "TRACE".
+func levelNameOfString(value string) string {
+	if IsValidLogLevelName(value) {
+		return value
+	}
+
+	for level, name := range LevelToTextMap {
+		if level.String() == value {
+			return name
+		}
+	}
+
+	return value
+}
+
+/*
+The level of a message whose MessageLevel detail names a custom level.
+Custom levels are registered only in this package, so go-messaging returns PANIC for them.
+*/
+func messageLevel(details []interface{}, logLevel slog.Level) slog.Level {
+	for _, detail := range slices.Backward(details) {
+		if detailLevel, ok := detail.(messenger.MessageLevel); ok {
+			if level, found := TextToLevelMap[detailLevel.Value]; found {
+				return level
+			}
+
+			return logLevel
+		}
+	}
+
+	return logLevel
+}
+
+/*
+Add a custom level to the level maps of this package.
+The level maps of go-messaging are globals of another module and are not changed;
+messageLevel() resolves custom levels instead.
+*/
+func registerLevel(customLevel CustomLevel) error {
+	level := slog.Level(customLevel.Value)
+
+	if customLevel.Name == "" {
+		return wraperror.Errorf(errForPackage, "custom level %d has no name", customLevel.Value)
+	}
+
+	if IsValidLogLevelName(customLevel.Name) {
+		return wraperror.Errorf(errForPackage, "level name already registered: %s", customLevel.Name)
+	}
+
+	if existingName, ok := LevelToTextMap[level]; ok {
+		return wraperror.Errorf(
+			errForPackage,
+			"level %d of %s already registered as %s",
+			customLevel.Value,
+			customLevel.Name,
+			existingName,
+		)
+	}
+
+	LevelToTextMap[level] = customLevel.Name
+	TextToLevelMap[customLevel.Name] = level
+
+	if customLevel.Always {
+		alwaysLevels[level] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package logging_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	auditLevelName  = "AUDIT"
+	noticeLevelName = "NOTICE"
+)
+
+var registerLevelsOnce sync.Once
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Levels are registered once, by tests that do not run in parallel, so parallel tests never see the registration.
+func registerTestLevels(test *testing.T) {
+	test.Helper()
+	registerLevelsOnce.Do(func() {
+		require.NoError(test, logging.RegisterLevel(logging.CustomLevel{Name: noticeLevelName, Value: 3}))
+		require.NoError(test, logging.RegisterLevel(logging.CustomLevel{Always: true, Name: auditLevelName, Value: 10}))
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest
+func TestRegisterLevel(test *testing.T) {
+	registerTestLevels(test)
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionLogLevel{Value: noticeLevelName},
+		logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+			{FirstMessageNumber: 0, LastMessageNumber: 999, LogLevel: logging.LevelInfoName},
+			{FirstMessageNumber: 1000, LastMessageNumber: 1999, LogLevel: noticeLevelName},
+			{FirstMessageNumber: 2000, LastMessageNumber: 2999, LogLevel: auditLevelName},
+		}},
+	)
+	require.NoError(test, err)
+
+	assert.True(test, logging.IsValidLogLevelName(noticeLevelName))
+	assert.NotContains(test, messenger.TextToLevelMap, noticeLevelName, "go-messaging levels are not changed")
+	assert.Equal(test, noticeLevelName, logger.GetLogLevel())
+	logger.Log(1)
+	logger.Log(1001)
+	assert.Equal(test, `{"level":"NOTICE","id":"1001"}`+"\n", outputString.String())
+
+	outputString.Reset()
+	require.NoError(test, logger.SetLogLevel(logging.LevelErrorName))
+	logger.Log(1001)
+	logger.Log(2001)
+	assert.Equal(test, `{"level":"AUDIT","id":"2001"}`+"\n", outputString.String())
+	assert.False(test, logger.Is(noticeLevelName))
+	assert.True(test, logger.Is(auditLevelName))
+	assert.True(test, logger.(logging.FilterLogging).IsEnabled(2001))
+}
+
+//nolint:paralleltest
+func TestRegisterLevel_bad(test *testing.T) {
+	registerTestLevels(test)
+
+	require.Error(test, logging.RegisterLevel(logging.CustomLevel{Value: 3}))
+	require.Error(test, logging.RegisterLevel(logging.CustomLevel{Name: noticeLevelName, Value: 3}))
+	require.Error(test, logging.RegisterLevel(logging.CustomLevel{Name: "SECURITY", Value: 3}))
+	require.Error(test, logging.RegisterLevel(logging.CustomLevel{Name: "SECURITY", Value: logging.LevelWarnInt}))
+}
+
+//nolint:paralleltest
+func TestRegisterLevel_sinks(test *testing.T) {
+	registerTestLevels(test)
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionIDLevelRanges{Value: []logging.IDLevelRange{
+			{FirstMessageNumber: 0, LastMessageNumber: 999, LogLevel: noticeLevelName},
+			{FirstMessageNumber: 1000, LastMessageNumber: 1999, LogLevel: auditLevelName},
+		}},
+		logging.OptionSinks{Value: []logging.Sink{
+			{Format: logging.FormatText, Name: "console", Output: consoleString, LogLevel: logging.LevelPanicName},
+			{Format: logging.FormatText, Name: "file", Output: fileString, LogLevel: noticeLevelName},
+		}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(1)
+	logger.Log(1001)
+	assert.Equal(test, "level=AUDIT id=1001\n", consoleString.String())
+	assert.Equal(test, "level=NOTICE id=1\nlevel=AUDIT id=1001\n", fileString.String())
+
+	sinkLogLevel, err := logger.(logging.SinkLogging).GetSinkLogLevel("file")
+	require.NoError(test, err)
+	assert.Equal(test, noticeLevelName, sinkLogLevel)
+}
+
+//nolint:paralleltest
+func TestSlogHandlerOptions_customLevel(test *testing.T) {
+	registerTestLevels(test)
+
+	replaceAttr := logging.SlogHandlerOptions(nil).ReplaceAttr
+	assert.Equal(test, noticeLevelName, replaceAttr(nil, slog.String(slog.LevelKey, "INFO+3")).Value.String())
+	assert.Equal(test, auditLevelName, replaceAttr(nil, slog.Any(slog.LevelKey, slog.Level(10))).Value.String())
+	assert.Equal(test, logging.LevelTraceName, replaceAttr(nil, slog.String(slog.LevelKey, "DEBUG-4")).Value.String())
+	assert.Equal(test, "WARN+1", replaceAttr(nil, slog.Any(slog.LevelKey, slog.Level(5))).Value.String())
+}
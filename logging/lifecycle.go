@@ -0,0 +1,218 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The time Close() gives each resource to flush and close, e.g. an OTLPHandler whose collector is down.
+// ActionPanic gives the logger the same time to flush before panicking.
+const closeTimeout = 5 * time.Second
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The lifecycle type holds what Flush() and Close() act on:
+the sampler, the duplicate filter, the async queue, and the outputs and handlers that records are written to.
+All loggers created by With() and WithGroup() share one lifecycle.
+*/
+type lifecycle struct {
+	asyncQueue *asyncQueue
+	closeErr   error
+	closeOnce  sync.Once
+	closed     atomic.Bool
+	duplicates *duplicateFilter
+	resources  []interface{}
+	sampler    *sampler
+}
+
+// Handlers like OTLPHandler are flushed with Flush(ctx).
+type contextFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Outputs like bufio.Writer are flushed with Flush().
+type flusher interface {
+	Flush() error
+}
+
+// Outputs like os.File are flushed with Sync().
+type syncer interface {
+	Sync() error
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Log sampling and duplicate summaries and write queued records, then flush and close each resource.
+// Only the first call has an effect.
+func (lifecycle *lifecycle) close() error {
+	lifecycle.closeOnce.Do(func() {
+		var errs []error
+
+		lifecycle.sampler.close()
+		lifecycle.duplicates.close()
+
+		if lifecycle.asyncQueue != nil {
+			errs = append(errs, lifecycle.asyncQueue.close())
+		}
+
+		for _, resource := range lifecycle.resources {
+			errs = append(errs, closeResource(resource))
+		}
+
+		lifecycle.closed.Store(true)
+		lifecycle.closeErr = errors.Join(errs...)
+	})
+
+	return lifecycle.closeErr
+}
+
+// Wait for queued records, then flush each resource, even if a queued record could not be written.
+// After Close(), nothing is flushed.
+func (lifecycle *lifecycle) flush(ctx context.Context) error {
+	if lifecycle.closed.Load() {
+		return nil
+	}
+
+	var errs []error
+
+	if lifecycle.asyncQueue != nil {
+		errs = append(errs, lifecycle.asyncQueue.flush(ctx))
+	}
+
+	for _, resource := range lifecycle.resources {
+		errs = append(errs, flushResource(ctx, resource))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Add an output or handler to resources.
+Handlers wrapping an output are replaced by the output.
+os.Stdout and os.Stderr are never added, so they are never closed.
+*/
+func appendResource(resources []interface{}, resource interface{}) []interface{} {
+	switch typedResource := resource.(type) {
+	case nil:
+		return resources
+	case *formatHandler:
+		return appendResource(resources, typedResource.output)
+	case *levelHandler:
+		return appendResource(resources, typedResource.handler)
+	case *stdlibHandler:
+		return appendResource(resources, typedResource.handler)
+	}
+
+	if resource == os.Stdout || resource == os.Stderr {
+		return resources
+	}
+
+	if slices.ContainsFunc(resources, func(existing interface{}) bool { return sameResource(existing, resource) }) {
+		return resources
+	}
+
+	return append(resources, resource)
+}
+
+/*
+Flush a resource and, if this package created it, close it, giving up after closeTimeout.
+Outputs and handlers of other types, e.g. an *os.File or net.Conn, belong to the caller and are only flushed.
+*/
+func closeResource(resource interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	flushErr := flushResource(ctx, resource)
+
+	var err error
+
+	switch typedResource := resource.(type) {
+	case *OTLPHandler:
+		err = typedResource.Shutdown(ctx)
+	case *RotatingFile:
+		err = typedResource.Close()
+	case *journaldWriter:
+		err = typedResource.Close()
+	case *syslogWriter:
+		err = typedResource.Close()
+	}
+
+	if err != nil {
+		return errors.Join(flushErr, wraperror.Errorf(err, "closeResource"))
+	}
+
+	return flushErr
+}
+
+func flushResource(ctx context.Context, resource interface{}) error {
+	var err error
+
+	switch typedResource := resource.(type) {
+	case contextFlusher:
+		err = typedResource.Flush(ctx)
+	case flusher:
+		err = typedResource.Flush()
+	case syncer:
+		err = typedResource.Sync()
+	}
+
+	if err != nil {
+		return wraperror.Errorf(err, "flushResource")
+	}
+
+	return nil
+}
+
+// The outputs and handlers that Flush() and Close() are propagated to.
+func newLifecycle(extractedValues *ExtractedValues, handler slog.Handler) *lifecycle {
+	var resources []interface{}
+
+	switch {
+	case len(extractedValues.sinks) > 0:
+		for _, sink := range extractedValues.sinks {
+			if sink.Handler != nil {
+				resources = appendResource(resources, sink.Handler)
+			} else {
+				resources = appendResource(resources, sink.Output)
+			}
+		}
+	case extractedValues.handlerFactory != nil:
+		resources = appendResource(resources, handler)
+	default:
+		resources = appendResource(resources, extractedValues.output)
+	}
+
+	return &lifecycle{resources: resources}
+}
+
+// Compare resources without panicking on values of types that are not comparable.
+func sameResource(resource1 interface{}, resource2 interface{}) bool {
+	resourceType := reflect.TypeOf(resource1)
+	if resourceType != reflect.TypeOf(resource2) || !resourceType.Comparable() {
+		return false
+	}
+
+	return resource1 == resource2
+}
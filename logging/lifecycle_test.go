@@ -0,0 +1,173 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An output counting calls of Flush() and Close().
+type lifecycleWriter struct {
+	bytes.Buffer
+
+	closes  atomic.Int32
+	flushes atomic.Int32
+}
+
+func (writer *lifecycleWriter) Close() error {
+	writer.closes.Add(1)
+
+	return nil
+}
+
+func (writer *lifecycleWriter) Flush() error {
+	writer.flushes.Add(1)
+
+	return nil
+}
+
+// An output counting calls of Flush() whose writes fail.
+type failingLifecycleWriter struct {
+	lifecycleWriter
+}
+
+func (writer *failingLifecycleWriter) Write(data []byte) (int, error) {
+	_ = data
+
+	return 0, errTest
+}
+
+// A handler counting calls of Flush(ctx) and Shutdown(ctx).
+type lifecycleHandler struct {
+	slog.Handler
+
+	flushes   atomic.Int32
+	shutdowns atomic.Int32
+}
+
+func (handler *lifecycleHandler) Flush(ctx context.Context) error {
+	_ = ctx
+
+	handler.flushes.Add(1)
+
+	return nil
+}
+
+func (handler *lifecycleHandler) Shutdown(ctx context.Context) error {
+	_ = ctx
+
+	handler.shutdowns.Add(1)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicLogging_Close(test *testing.T) {
+	test.Parallel()
+
+	writer := &lifecycleWriter{}
+	logger, err := logging.New(logging.OptionOutput{Value: writer})
+	require.NoError(test, err)
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Flush(test.Context()))
+	assert.Equal(test, int32(1), writer.flushes.Load())
+
+	require.NoError(test, logger.(logging.AttrLogging).With("jobID", "job-20").(logging.LifecycleLogging).Close())
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, int32(2), writer.flushes.Load())
+	assert.Equal(test, int32(0), writer.closes.Load(), "an output of the caller is not closed")
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Flush(test.Context()))
+	assert.Equal(test, int32(2), writer.flushes.Load())
+}
+
+func TestBasicLogging_Close_concurrent(test *testing.T) {
+	test.Parallel()
+
+	writer := &lifecycleWriter{}
+	logger, err := logging.New(logging.OptionAsync{Value: true}, logging.OptionOutput{Value: writer})
+	require.NoError(test, err)
+	logger.Log(2001)
+
+	var waitGroup sync.WaitGroup
+
+	for range 10 {
+		waitGroup.Go(func() {
+			assert.NoError(test, logger.(logging.LifecycleLogging).Close())
+		})
+	}
+
+	waitGroup.Wait()
+	assert.Equal(test, int32(1), writer.flushes.Load())
+}
+
+func TestBasicLogging_Close_handler(test *testing.T) {
+	test.Parallel()
+
+	handler := &lifecycleHandler{Handler: slog.NewJSONHandler(new(bytes.Buffer), nil)}
+	logger, err := logging.New(logging.OptionHandler{Value: handler})
+	require.NoError(test, err)
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Flush(test.Context()))
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, int32(2), handler.flushes.Load())
+	assert.Equal(test, int32(0), handler.shutdowns.Load(), "a handler of the caller is not shut down")
+}
+
+func TestBasicLogging_Close_rotatingFile(test *testing.T) {
+	test.Parallel()
+
+	rotatingFile, err := logging.NewRotatingFile(filepath.Join(test.TempDir(), "test.log"))
+	require.NoError(test, err)
+
+	logger, err := logging.New(logging.OptionOutput{Value: rotatingFile})
+	require.NoError(test, err)
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	_, err = rotatingFile.Write([]byte("after Close\n"))
+	require.Error(test, err)
+}
+
+func TestBasicLogging_Flush_asyncError(test *testing.T) {
+	test.Parallel()
+
+	writer := &failingLifecycleWriter{}
+	logger, err := logging.New(logging.OptionOutput{Value: writer}, logging.OptionAsync{Value: true})
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	require.Error(test, logger.(logging.LifecycleLogging).Flush(context.Background()))
+	assert.Equal(test, int32(1), writer.flushes.Load())
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+}
+
+func TestBasicLogging_Close_sinks(test *testing.T) {
+	test.Parallel()
+
+	writer := &lifecycleWriter{}
+	logger, err := logging.New(
+		logging.OptionSink{Value: logging.Sink{Name: "json", Output: writer}},
+		logging.OptionSink{Value: logging.Sink{Name: "logfmt", Format: logging.FormatLogfmt, Output: writer}},
+		logging.OptionSink{Value: logging.Sink{Name: "stderr"}},
+	)
+	require.NoError(test, err)
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, int32(1), writer.flushes.Load())
+	assert.Equal(test, int32(0), writer.closes.Load())
+}
@@ -0,0 +1,245 @@
+package logging
+
+import (
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+LocalizedCatalogs holds a Catalog for each locale, keyed by BCP 47 language tag, e.g. "en", "fr", or "fr-CA".
+Message numbers, and so message IDs, are the same in every locale; only the messages differ.
+*/
+type LocalizedCatalogs map[string]*Catalog
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The locale every fallback chain ends with.
+const LocaleEnglish = "en"
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Catalog method returns the messages for a locale.
+The text of each message comes from the first locale of the fallback chain having it,
+e.g. for "fr-CA": "fr-CA", then "fr", then "en".
+The level, status, remediation, and documentation URL come from the last locale of the chain having the message,
+usually "en", so translations cannot change how a message is handled.
+
+Input
+  - locale: A BCP 47 language tag, e.g. "fr-CA", or a POSIX locale, e.g. "fr_CA.UTF-8".
+
+Output
+  - A Catalog.
+*/
+func (catalogs LocalizedCatalogs) Catalog(locale string) *Catalog {
+	result := &Catalog{Messages: map[int]CatalogMessage{}}
+	chain := localeFallbacks(locale)
+
+	for _, fallback := range slices.Backward(chain) {
+		catalog := catalogs.find(fallback)
+		if catalog == nil {
+			continue
+		}
+
+		for messageNumber, message := range catalog.Messages {
+			baseMessage, ok := result.Messages[messageNumber]
+			if !ok {
+				result.Messages[messageNumber] = message
+
+				continue
+			}
+
+			baseMessage.Text = message.Text
+			result.Messages[messageNumber] = baseMessage
+		}
+	}
+
+	return result
+}
+
+/*
+The MissingIDs method reports, for each locale, the message numbers other locales have but it does not.
+Use it to find messages needing translation.
+
+Output
+  - A map of locale to sorted message numbers.  Locales missing no messages are not included.
+*/
+func (catalogs LocalizedCatalogs) MissingIDs() map[string][]int {
+	allMessageNumbers := map[int]bool{}
+
+	for _, catalog := range catalogs {
+		if catalog == nil {
+			continue
+		}
+
+		for messageNumber := range catalog.Messages {
+			allMessageNumbers[messageNumber] = true
+		}
+	}
+
+	result := map[string][]int{}
+
+	for locale, catalog := range catalogs {
+		var missing []int
+
+		for _, messageNumber := range slices.Sorted(maps.Keys(allMessageNumbers)) {
+			if catalog == nil {
+				missing = append(missing, messageNumber)
+
+				continue
+			}
+
+			if _, ok := catalog.Messages[messageNumber]; !ok {
+				missing = append(missing, messageNumber)
+			}
+		}
+
+		if len(missing) > 0 {
+			result[locale] = missing
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LoadLocalizedCatalogsFS function reads a catalog file for each locale from a directory of a file system,
+e.g. an embed.FS.
+Files are named by locale, e.g. "en.yaml", "fr.json", "fr-CA.toml".
+Files with other extensions are ignored.
+
+Input
+  - fsys: The file system.
+  - dir: The directory of the catalog files.
+
+Output
+  - The LocalizedCatalogs.
+  - error
+*/
+func LoadLocalizedCatalogsFS(fsys fs.FS, dir string) (LocalizedCatalogs, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "LoadLocalizedCatalogsFS")
+	}
+
+	result := LocalizedCatalogs{}
+
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name())
+		if entry.IsDir() || !slices.Contains([]string{".json", ".toml", ".yaml", ".yml"}, strings.ToLower(extension)) {
+			continue
+		}
+
+		locale := normalizeLocale(strings.TrimSuffix(entry.Name(), extension))
+		if _, ok := result[locale]; ok {
+			return nil, wraperror.Errorf(errForPackage, "more than one catalog file for locale %s", locale)
+		}
+
+		catalog, err := LoadCatalogFS(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		result[locale] = catalog
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The catalog of a locale, matching tags regardless of case and separator.
+func (catalogs LocalizedCatalogs) find(locale string) *Catalog {
+	for key, catalog := range catalogs {
+		if normalizeLocale(key) == locale {
+			return catalog
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The locale chosen by LC_ALL, LC_MESSAGES, or LANG, in that order.  English if none is set.
+func environmentLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return normalizeLocale(value)
+		}
+	}
+
+	return LocaleEnglish
+}
+
+// The locale and less specific locales, ending with English, e.g. "fr-CA", "fr", "en".
+func localeFallbacks(locale string) []string {
+	var result []string
+
+	locale = normalizeLocale(locale)
+	for locale != "" {
+		result = append(result, locale)
+
+		index := strings.LastIndex(locale, "-")
+		if index < 0 {
+			break
+		}
+
+		locale = locale[:index]
+	}
+
+	if !slices.Contains(result, LocaleEnglish) {
+		result = append(result, LocaleEnglish)
+	}
+
+	return result
+}
+
+// A BCP 47 tag in canonical case, e.g. "fr_ca.UTF-8" becomes "fr-CA".  POSIX "C" and "POSIX" are English.
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.TrimSpace(locale)
+
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return LocaleEnglish
+	}
+
+	subtags := strings.FieldsFunc(locale, func(character rune) bool { return character == '-' || character == '_' })
+	for index, subtag := range subtags {
+		switch {
+		case index == 0:
+			subtags[index] = strings.ToLower(subtag)
+		case len(subtag) == 2: //nolint:mnd
+			subtags[index] = strings.ToUpper(subtag)
+		case len(subtag) == 4: //nolint:mnd
+			subtags[index] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[index] = strings.ToLower(subtag)
+		}
+	}
+
+	return strings.Join(subtags, "-")
+}
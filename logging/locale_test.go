@@ -0,0 +1,153 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var localizedCatalogFilesTest = fstest.MapFS{ //nolint
+	"catalogs/en.yaml": {Data: []byte(`
+messages:
+  2001: {text: "%s works with %s"}
+  2002: {text: "%s is idle"}
+  2003: {text: "%s went home"}
+`)},
+	"catalogs/fr.json": {Data: []byte(`{"messages": {
+  "2001": {"text": "%s travaille avec %s"},
+  "2002": {"text": "%s est inactif"}
+}}`)},
+	"catalogs/fr_ca.toml": {Data: []byte(`
+[messages.2001]
+text = "%s bosse avec %s"
+`)},
+	"catalogs/README.md": {Data: []byte(`Not a catalog.`)},
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func loadLocalizedCatalogs(test *testing.T) logging.LocalizedCatalogs {
+	test.Helper()
+
+	catalogs, err := logging.LoadLocalizedCatalogsFS(localizedCatalogFilesTest, "catalogs")
+	require.NoError(test, err)
+
+	return catalogs
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLocalizedCatalogs_Catalog(test *testing.T) {
+	test.Parallel()
+
+	catalogs := loadLocalizedCatalogs(test)
+	require.Len(test, catalogs, 3)
+	assert.Contains(test, catalogs, "fr-CA")
+
+	assert.Equal(test, map[int]string{
+		2001: "%s bosse avec %s",
+		2002: "%s est inactif",
+		2003: "%s went home",
+	}, catalogs.Catalog("fr_CA.UTF-8").IDMessages())
+	assert.Equal(test, "%s travaille avec %s", catalogs.Catalog("fr-BE").IDMessages()[2001])
+	assert.Equal(test, "%s works with %s", catalogs.Catalog("de-DE").IDMessages()[2001])
+	assert.Equal(test, "%s works with %s", catalogs.Catalog("C").IDMessages()[2001])
+}
+
+func TestLocalizedCatalogs_Catalog_baseFields(test *testing.T) {
+	test.Parallel()
+
+	catalogs := logging.LocalizedCatalogs{
+		"en": {Messages: map[int]logging.CatalogMessage{
+			2001: {Text: "Hello %s", Level: logging.LevelErrorName, Status: "FAILURE"},
+		}},
+		"fr": {Messages: map[int]logging.CatalogMessage{
+			2001: {Text: "Bonjour %s"},
+		}},
+	}
+
+	assert.Equal(
+		test,
+		logging.CatalogMessage{Text: "Bonjour %s", Level: logging.LevelErrorName, Status: "FAILURE"},
+		catalogs.Catalog("fr").Messages[2001],
+	)
+}
+
+func TestLocalizedCatalogs_MissingIDs(test *testing.T) {
+	test.Parallel()
+
+	assert.Equal(test, map[string][]int{
+		"fr":    {2003},
+		"fr-CA": {2002, 2003},
+	}, loadLocalizedCatalogs(test).MissingIDs())
+}
+
+func TestLogging_New_optionLocale(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		nil,
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text"}},
+		logging.OptionLocale{Value: "fr-CA"},
+		logging.OptionLocalizedCatalogs{Value: loadLocalizedCatalogs(test)},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2003, "Bob")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"Bob bosse avec Jane","id":"SZTL99972001"}`+"\n"+
+			`{"level":"INFO","text":"Bob went home","id":"SZTL99972003"}`+"\n",
+		outputString.String(),
+	)
+}
+
+//nolint:paralleltest
+func TestLogging_New_optionLocalizedCatalogs_environment(test *testing.T) {
+	test.Setenv("LC_ALL", "")
+	test.Setenv("LC_MESSAGES", "fr_FR.UTF-8")
+	test.Setenv("LANG", "de_DE.UTF-8")
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text"}},
+		logging.OptionLocalizedCatalogs{Value: loadLocalizedCatalogs(test)},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2002, "Bob")
+	assert.Equal(test, `{"level":"INFO","text":"Bob est inactif","id":"2002"}`+"\n", outputString.String())
+}
+
+func TestLoadLocalizedCatalogsFS_bad(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.LoadLocalizedCatalogsFS(localizedCatalogFilesTest, "missing")
+	require.Error(test, err)
+
+	_, err = logging.LoadLocalizedCatalogsFS(fstest.MapFS{
+		"catalogs/fr.json": {Data: []byte(`{"messages": {"2001": {"text": "A"}}}`)},
+		"catalogs/FR.yaml": {Data: []byte(`messages: {2001: {text: "B"}}`)},
+	}, "catalogs")
+	require.ErrorContains(test, err, "fr")
+
+	_, err = logging.LoadLocalizedCatalogsFS(fstest.MapFS{
+		"catalogs/fr.json": {Data: []byte(`{"messages": {"20x1": {"text": "A"}}}`)},
+	}, "catalogs")
+	require.ErrorContains(test, err, "20x1")
+}
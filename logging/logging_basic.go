@@ -2,7 +2,9 @@ package logging
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
@@ -17,11 +19,24 @@ import (
 // BasicLogging is an type-struct for an implementation of the loggingInterface.
 type BasicLogging struct {
 	// Using Ctx is not a preferred practice, but used to simplify Log() calls.
-	Ctx          context.Context //nolint
-	messenger    messenger.Messenger
-	logger       *slog.Logger
-	leveler      *slog.LevelVar
-	logLevelName string
+	Ctx                 context.Context //nolint
+	actions             *levelActions
+	attrs               []slog.Attr
+	callerSkip          int
+	contextExtractors   []ContextExtractor
+	groups              []string
+	idDocumentationURLs map[int]string
+	idFilters           *idFilters
+	idLevelRanges       *idLevelRanges
+	idRemediations      map[int]string
+	messenger           messenger.Messenger
+	logger              *slog.Logger
+	leveler             *slog.LevelVar
+	lifecycle           *lifecycle
+	placeholders        *placeholders
+	sinkLevelers        map[string]*sinkLeveler
+	sinkMessageFields   []string
+	templateChecker     *templateChecker
 }
 
 // ----------------------------------------------------------------------------
@@ -30,27 +45,128 @@ type BasicLogging struct {
 
 /*
 The Error method returns an error with a JSON message based on the messageNumber and details.
+Attributes added by With() are added to the details.
 
 Input
   - messageNumber: A message identifier which indexes into "idMessages".
   - details: Variadic arguments of any type to be added to the message.
 
 Output
-  - error
+  - An *Error.  Error values in the details are returned by its Unwrap() method.
 */
 func (loggingImpl *BasicLogging) NewError(messageNumber int, details ...interface{}) error {
-	transformedDetails := transformDetails(details...)
-	return errors.New(loggingImpl.messenger.NewJSON(messageNumber, transformedDetails...)) //nolint
+	loggingImpl.checkTemplate(loggingImpl.Ctx, messageNumber, details)
+	transformedDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, transformDetails(details...))
+	transformedDetails = loggingImpl.appendText(loggingImpl.Ctx, messageNumber, details, transformedDetails)
+	transformedDetails = append(transformedDetails, attrsAsDetails(loggingImpl.attrs)...)
+
+	text := loggingImpl.messenger.NewJSON(messageNumber, transformedDetails...)
+
+	return loggingImpl.newError(text, messageNumber, details, transformedDetails)
+}
+
+/*
+The NewErrorContext method returns an error with a JSON message based on the messageNumber and details.
+Values found by the ContextExtractors are added to the details.
+Attributes added by With() are also added to the details.
+
+Input
+  - ctx: A context to extract values from.
+  - messageNumber: A message identifier which indexes into "idMessages".
+  - details: Variadic arguments of any type to be added to the message.
+
+Output
+  - An *Error.  Error values in the details are returned by its Unwrap() method.
+*/
+func (loggingImpl *BasicLogging) NewErrorContext(ctx context.Context, messageNumber int, details ...interface{}) error {
+	loggingImpl.checkTemplate(ctx, messageNumber, details)
+	transformedDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, transformDetails(details...))
+	transformedDetails = loggingImpl.appendText(ctx, messageNumber, details, transformedDetails)
+	attrs := append(slices.Clone(loggingImpl.attrs), loggingImpl.contextAttrs(ctx)...)
+	transformedDetails = append(transformedDetails, attrsAsDetails(attrs)...)
+
+	text := loggingImpl.messenger.NewJSON(messageNumber, transformedDetails...)
+
+	return loggingImpl.newError(text, messageNumber, details, transformedDetails)
+}
+
+/*
+The Close method writes records queued by OptionAsync, stops the background writer,
+then flushes the outputs and handlers that records are written to.
+Only outputs and handlers created by this package are closed:
+a RotatingFile and the handlers from NewJournaldHandler(), NewOTLPHandler(), and NewSyslogHandler().
+Other outputs, e.g. an *os.File, pipe, or connection given to OptionOutput, belong to the caller and stay open.
+Each is given 5 seconds to flush and close, so an unreachable OTLP collector does not block Close().
+Close() may be called more than once and from any goroutine, e.g. one receiving os.Signal values.
+Loggers created by With() and WithGroup() share the outputs, so closing one closes all.
+
+Output
+  - error
+*/
+func (loggingImpl *BasicLogging) Close() error {
+	return loggingImpl.lifecycle.close()
+}
+
+/*
+The DroppedRecords method returns the number of records dropped
+because the OptionAsync queue was full.
+
+Output
+  - The number of dropped records.
+*/
+func (loggingImpl *BasicLogging) DroppedRecords() uint64 {
+	if loggingImpl.lifecycle.asyncQueue == nil {
+		return 0
+	}
+
+	return loggingImpl.lifecycle.asyncQueue.dropped.Load()
+}
+
+/*
+The Flush method waits until records queued by OptionAsync are written,
+then flushes the outputs and handlers that records are written to.
+Outputs having a Flush() or Sync() method, e.g. *os.File, and handlers having a Flush(ctx) method are flushed.
+After Close(), Flush() does nothing.
+
+Input
+  - ctx: Stops the wait when done.
+
+Output
+  - error: The first error from writing queued records, the error of ctx, or errors from flushing.
+*/
+func (loggingImpl *BasicLogging) Flush(ctx context.Context) error {
+	return loggingImpl.lifecycle.flush(ctx)
 }
 
 /*
 The GetLogLevel method retrieves the current log level name.
 
 Output
-  - One of the following string values: "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", or "PANIC"
+  - One of the following string values: "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC",
+    or a name added with RegisterLevel()
 */
 func (loggingImpl *BasicLogging) GetLogLevel() string {
-	return loggingImpl.logLevelName
+	return levelName(loggingImpl.leveler.Level())
+}
+
+/*
+The GetSinkLogLevel method retrieves the current log level name of a sink.
+
+Input
+  - name: The Name of the Sink.
+
+Output
+  - One of the following string values: "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC",
+    or a name added with RegisterLevel()
+  - error
+*/
+func (loggingImpl *BasicLogging) GetSinkLogLevel(name string) (string, error) {
+	sinkLeveler, ok := loggingImpl.sinkLevelers[name]
+	if !ok {
+		return "", wraperror.Errorf(errForPackage, "unknown sink: %s", name)
+	}
+
+	return levelName(sinkLeveler.Level()), nil
 }
 
 /*
@@ -64,7 +180,29 @@ func (loggingImpl *BasicLogging) Is(logLevelName string) bool {
 	logLevel, ok := TextToLevelMap[logLevelName]
 
 	if ok {
-		result = loggingImpl.logger.Enabled(loggingImpl.Ctx, logLevel)
+		result = isAlwaysLevel(logLevel) || loggingImpl.logger.Enabled(loggingImpl.Ctx, logLevel)
+	}
+
+	return result
+}
+
+/*
+The IsContext method is used to determine if a log message will be printed using the context.
+
+Input
+  - ctx: The context that would be used with LogContext().
+  - logLevelName: One of these strings:  "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC",
+    or a name added with RegisterLevel().
+
+Output
+  - True, if message would be logged at the logLevelName level.
+*/
+func (loggingImpl *BasicLogging) IsContext(ctx context.Context, logLevelName string) bool {
+	result := false
+	logLevel, ok := TextToLevelMap[logLevelName]
+
+	if ok {
+		result = isAlwaysLevel(logLevel) || loggingImpl.logger.Enabled(ctx, logLevel)
 	}
 
 	return result
@@ -80,6 +218,31 @@ func (loggingImpl *BasicLogging) IsDebug() bool {
 	return loggingImpl.Is(LevelDebugName)
 }
 
+/*
+The IsEnabled method is used to determine if a message will be logged.
+IDFilters are applied first.  Otherwise the level of the message number is compared to the log level.
+The level of the message number comes from OptionIDLevelRanges or IDLevelRangesAsString.
+
+Input
+  - messageNumber: A message identifier which indexes into "idMessages".
+
+Output
+  - True, if the message would be logged.
+*/
+func (loggingImpl *BasicLogging) IsEnabled(messageNumber int) bool {
+	filter, ok := loggingImpl.idFilters.find(messageNumber)
+	if ok {
+		return filter.Enabled
+	}
+
+	levelName, ok := loggingImpl.idLevelRanges.levelName(messageNumber)
+	if !ok {
+		return false
+	}
+
+	return loggingImpl.Is(levelName)
+}
+
 /*
 The IsError method is used to determine if ERROR messages will be logged.
 
@@ -142,6 +305,7 @@ func (loggingImpl *BasicLogging) IsWarn() bool {
 
 /*
 The Json method returns a JSON string based on the messageNumber and details.
+Attributes added by With() are added to the details.
 
 Input
   - messageNumber: A message identifier which indexes into "idMessages".
@@ -151,7 +315,10 @@ Output
   - JSON string with message key/value pairs.
 */
 func (loggingImpl *BasicLogging) JSON(messageNumber int, details ...interface{}) string {
-	transformedDetails := transformDetails(details...)
+	loggingImpl.checkTemplate(loggingImpl.Ctx, messageNumber, details)
+	transformedDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, transformDetails(details...))
+	transformedDetails = loggingImpl.appendText(loggingImpl.Ctx, messageNumber, details, transformedDetails)
+	transformedDetails = append(transformedDetails, attrsAsDetails(loggingImpl.attrs)...)
 
 	return loggingImpl.messenger.NewJSON(messageNumber, transformedDetails...)
 }
@@ -164,20 +331,79 @@ Input
   - details: Variadic arguments of any type to be added to the message.
 */
 func (loggingImpl *BasicLogging) Log(messageNumber int, details ...interface{}) {
-	transformedDetails := transformDetails(details...)
+	ctx, ok := loggingImpl.filter(loggingImpl.Ctx, messageNumber, details)
+	if !ok {
+		return
+	}
+
+	loggingImpl.checkTemplate(ctx, messageNumber, details)
+	transformedDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, transformDetails(details...))
+	transformedDetails = loggingImpl.appendText(ctx, messageNumber, details, transformedDetails)
+	message, logLevel, newDetails := loggingImpl.messenger.NewSlogLevel(
+		messageNumber,
+		loggingImpl.withSinkMessageFields(transformedDetails)...,
+	)
+	logLevel = messageLevel(transformedDetails, logLevel)
+	newDetails = loggingImpl.appendCatalogAttrs(messageNumber, newDetails)
+
+	if loggingImpl.log(ctx, logLevel, message, newDetails) {
+		loggingImpl.actions.act(ctx, loggingImpl, logLevel, messageNumber, details, transformedDetails)
+	}
+}
+
+/*
+The LogContext method writes a log record using the context.
+Values found by the ContextExtractors are added to the log record.
+
+Input
+  - ctx: A context passed to the underlying slog.Handler.
+  - messageNumber: A message identifier which indexes into "idMessages".
+  - details: Variadic arguments of any type to be added to the message.
+*/
+func (loggingImpl *BasicLogging) LogContext(ctx context.Context, messageNumber int, details ...interface{}) {
+	ctx, ok := loggingImpl.filter(ctx, messageNumber, details)
+	if !ok {
+		return
+	}
+
+	loggingImpl.checkTemplate(ctx, messageNumber, details)
+	transformedDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, transformDetails(details...))
+	transformedDetails = loggingImpl.appendText(ctx, messageNumber, details, transformedDetails)
 	message, logLevel, newDetails := loggingImpl.messenger.NewSlogLevel(
 		messageNumber,
-		transformedDetails...,
+		loggingImpl.withSinkMessageFields(transformedDetails)...,
 	)
-	newTransformedDetails := transformDetails(newDetails...)
-	loggingImpl.logger.Log(loggingImpl.Ctx, logLevel, message, newTransformedDetails...)
+	logLevel = messageLevel(transformedDetails, logLevel)
+	newDetails = loggingImpl.appendCatalogAttrs(messageNumber, newDetails)
+
+	if loggingImpl.log(ctx, logLevel, message, newDetails) {
+		loggingImpl.actions.act(ctx, loggingImpl, logLevel, messageNumber, details, transformedDetails)
+	}
+}
+
+/*
+The SetIDFilters method replaces the IDFilters.
+It is safe to call while messages are logged.
+Loggers created by With() and WithGroup() share the IDFilters.
+
+Input
+  - filters: The new IDFilters.  An empty list removes all IDFilters.
+
+Output
+  - error
+*/
+func (loggingImpl *BasicLogging) SetIDFilters(filters []IDFilter) error {
+	return loggingImpl.idFilters.set(filters)
 }
 
 /*
 The SetLogLevel method changes the level of log messages generated.
+Sinks without a Sink.LogLevel follow the change.
+Sinks whose level was set by Sink.LogLevel or SetSinkLogLevel() keep their level.
 
 Input
-  - logLevelName: One of these strings:  "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", or "PANIC".
+  - logLevelName: One of these strings:  "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC",
+    or a name added with RegisterLevel().
 
 Output
   - error
@@ -191,15 +417,234 @@ func (loggingImpl *BasicLogging) SetLogLevel(logLevelName string) error {
 	}
 
 	loggingImpl.leveler.Set(slogLevel)
-	loggingImpl.logLevelName = logLevelName
 
 	return err
 }
 
+/*
+The SetSinkLogLevel method changes the level of log messages written to one Sink.
+Other sinks are not changed.
+
+Input
+  - name: The Name of the Sink.
+  - logLevelName: One of these strings:  "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC",
+    or a name added with RegisterLevel().
+
+Output
+  - error
+*/
+func (loggingImpl *BasicLogging) SetSinkLogLevel(name string, logLevelName string) error {
+	sinkLeveler, ok := loggingImpl.sinkLevelers[name]
+	if !ok {
+		return wraperror.Errorf(errForPackage, "unknown sink: %s", name)
+	}
+
+	slogLevel, ok := TextToLevelMap[logLevelName]
+	if !ok {
+		return wraperror.Errorf(errForPackage, "unknown error level: %s", logLevelName)
+	}
+
+	sinkLeveler.set(slogLevel)
+
+	return nil
+}
+
+/*
+The TemplateViolations method returns the number of messages whose details did not match
+the fmt verbs of their template.  Messages are only checked when OptionStrict is used.
+Loggers created by With() and WithGroup() share the count.
+
+Output
+  - The number of messages not matching their template.
+*/
+func (loggingImpl *BasicLogging) TemplateViolations() uint64 {
+	if loggingImpl.templateChecker == nil {
+		return 0
+	}
+
+	return loggingImpl.templateChecker.violations.Load()
+}
+
+/*
+The With method returns an AttrLogging that adds attributes to each message.
+The returned AttrLogging shares the level, output, and message templates of the original.
+
+Input
+  - details: Alternating keys and values, or slog.Attr values, in the style of slog.Logger.With().
+
+Output
+  - An AttrLogging which adds the attributes to each log record.
+    For JSON(), NewError(), and NewErrorContext(), the attributes are added to the "details" of the message.
+*/
+func (loggingImpl *BasicLogging) With(details ...interface{}) AttrLogging {
+	attrs := slog.Group("", details...).Value.Group()
+	if len(attrs) == 0 {
+		return loggingImpl
+	}
+
+	result := loggingImpl.clone()
+	result.logger = loggingImpl.logger.With(groupAttrs(loggingImpl.groups, attrs))
+
+	for _, attr := range attrs {
+		result.attrs = append(result.attrs, qualifyAttr(loggingImpl.groups, attr))
+	}
+
+	return result
+}
+
+/*
+The WithGroup method returns an AttrLogging that qualifies the attributes of later With() calls with a group name.
+The message fields (e.g. "id", "text", "details") are not qualified.
+
+Input
+  - name: The name of the group.
+
+Output
+  - An AttrLogging which qualifies attributes added by With().
+*/
+func (loggingImpl *BasicLogging) WithGroup(name string) AttrLogging {
+	if name == "" {
+		return loggingImpl
+	}
+
+	result := loggingImpl.clone()
+	result.groups = append(slices.Clone(loggingImpl.groups), name)
+
+	return result
+}
+
 // ----------------------------------------------------------------------------
 // Private methods
 // ----------------------------------------------------------------------------
 
+// Add the text of a message template having named placeholders to the details.
+// A diagnostic is logged for each placeholder that cannot be resolved.
+func (loggingImpl *BasicLogging) appendText(
+	ctx context.Context,
+	messageNumber int,
+	details []interface{},
+	transformedDetails []interface{},
+) []interface{} {
+	result, unresolved := loggingImpl.placeholders.appendText(
+		messageNumber,
+		details,
+		loggingImpl.attrs,
+		transformedDetails,
+	)
+	for _, name := range unresolved {
+		loggingImpl.logDiagnostic(
+			ctx,
+			loggingImpl.placeholders.diagnosticID,
+			messageNumber,
+			fmt.Sprintf("cannot resolve placeholder {%s} of message %d", name, messageNumber),
+			slog.String("placeholder", name),
+		)
+	}
+
+	return result
+}
+
+// Add the remediation and documentation URL of the message from OptionCatalog.
+func (loggingImpl *BasicLogging) appendCatalogAttrs(messageNumber int, details []interface{}) []interface{} {
+	if remediation, ok := loggingImpl.idRemediations[messageNumber]; ok {
+		details = append(details, slog.String("remediation", remediation))
+	}
+
+	if documentationURL, ok := loggingImpl.idDocumentationURLs[messageNumber]; ok {
+		details = append(details, slog.String("documentationUrl", documentationURL))
+	}
+
+	return details
+}
+
+// With OptionStrict, log a diagnostic for each mismatch between the details and the fmt verbs of the message template.
+func (loggingImpl *BasicLogging) checkTemplate(ctx context.Context, messageNumber int, details []interface{}) {
+	for _, problem := range loggingImpl.templateChecker.check(messageNumber, details) {
+		loggingImpl.logDiagnostic(
+			ctx,
+			loggingImpl.templateChecker.diagnosticID,
+			messageNumber,
+			fmt.Sprintf("message %d: %s", messageNumber, problem),
+		)
+	}
+}
+
+// Attributes from all ContextExtractors.
+func (loggingImpl *BasicLogging) contextAttrs(ctx context.Context) []slog.Attr {
+	var result []slog.Attr
+
+	if ctx == nil {
+		return result
+	}
+
+	for _, contextExtractor := range loggingImpl.contextExtractors {
+		result = append(result, contextExtractor(ctx)...)
+	}
+
+	return result
+}
+
+// A shallow copy sharing messenger, logger, leveler, sink levelers, and lifecycle.
+func (loggingImpl *BasicLogging) clone() *BasicLogging {
+	result := *loggingImpl
+	result.attrs = slices.Clip(loggingImpl.attrs)
+	result.groups = slices.Clip(loggingImpl.groups)
+
+	return &result
+}
+
+/*
+Decide if a message is logged, applying IDFilters, the log level, OptionSampling, and duplicate collapsing.
+Sampling and duplicate collapsing only see messages that pass the log level, so they do not count dropped messages.
+FATAL and PANIC messages are never sampled or collapsed, so OptionFatalAction and OptionPanicAction always apply.
+If an IDFilter enables the message, the returned context marks it to be logged regardless of log levels.
+*/
+func (loggingImpl *BasicLogging) filter(
+	ctx context.Context,
+	messageNumber int,
+	details []interface{},
+) (context.Context, bool) {
+	if ctx == nil {
+		ctx = loggingImpl.Ctx
+	}
+
+	filter, filtered := loggingImpl.idFilters.find(messageNumber)
+	if (filtered && !filter.Enabled) || loggingImpl.idLevelRanges.drops(messageNumber) {
+		return ctx, false
+	}
+
+	if filtered {
+		ctx = withForced(ctx)
+	} else if !loggingImpl.levelEnabled(ctx, messageNumber, details) {
+		return ctx, false
+	}
+
+	if logLevel, ok := loggingImpl.messageLogLevel(messageNumber, details); ok && logLevel >= LevelFatalSlog {
+		return ctx, true
+	}
+
+	if !loggingImpl.lifecycle.sampler.allow(messageNumber) ||
+		!loggingImpl.lifecycle.duplicates.allow(ctx, loggingImpl, messageNumber, details) {
+		return ctx, false
+	}
+
+	return ctx, true
+}
+
+// An *Error having the remediation and documentation URL of the message from OptionCatalog.
+func (loggingImpl *BasicLogging) newError(
+	text string,
+	messageNumber int,
+	details []interface{},
+	transformedDetails []interface{},
+) *Error {
+	result := newError(loggingImpl.messenger, text, messageNumber, details, transformedDetails)
+	result.documentationURL = loggingImpl.idDocumentationURLs[messageNumber]
+	result.remediation = loggingImpl.idRemediations[messageNumber]
+
+	return result
+}
+
 func (loggingImpl *BasicLogging) initialize() {
 	if loggingImpl.Ctx == nil {
 		loggingImpl.Ctx = context.Background()
@@ -216,16 +661,240 @@ func (loggingImpl *BasicLogging) initialize() {
 	if loggingImpl.leveler == nil {
 		panic("LoggingImpl.leveler is nil")
 	}
+}
+
+// Returns true if the level of the message is logged.
+func (loggingImpl *BasicLogging) levelEnabled(ctx context.Context, messageNumber int, details []interface{}) bool {
+	logLevel, ok := loggingImpl.messageLogLevel(messageNumber, details)
 
-	if loggingImpl.logLevelName == "" {
-		loggingImpl.logLevelName = LevelInfoName
+	return !ok || isAlwaysLevel(logLevel) || loggingImpl.logger.Enabled(ctx, logLevel)
+}
+
+// The level of the message, from a messenger.MessageLevel detail or the message number.  False if it is unknown.
+func (loggingImpl *BasicLogging) messageLogLevel(messageNumber int, details []interface{}) (slog.Level, bool) {
+	levelName, _ := loggingImpl.idLevelRanges.levelName(messageNumber)
+
+	for _, detail := range details {
+		if messageLevel, ok := detail.(messenger.MessageLevel); ok {
+			levelName = messageLevel.Value
+		}
 	}
+
+	logLevel, ok := TextToLevelMap[levelName]
+
+	return logLevel, ok
+}
+
+// Write a record.  Returns true if the record was written.
+func (loggingImpl *BasicLogging) log(
+	ctx context.Context,
+	logLevel slog.Level,
+	message string,
+	details []interface{},
+) bool {
+	if ctx == nil {
+		ctx = loggingImpl.Ctx
+	}
+
+	transformedDetails := transformDetails(details...)
+	if attrs := recordAttrs(ctx); len(attrs) > 0 {
+		transformedDetails = append([]interface{}{groupAttrs(loggingImpl.groups, attrs)}, transformedDetails...)
+	}
+
+	for _, attr := range loggingImpl.contextAttrs(ctx) {
+		transformedDetails = append(transformedDetails, attr)
+	}
+
+	if isAlwaysLevel(logLevel) {
+		ctx = withForced(ctx)
+	}
+
+	if !loggingImpl.logger.Enabled(ctx, logLevel) {
+		if !isForced(ctx) {
+			return false
+		}
+
+		record := slog.NewRecord(time.Now(), logLevel, message, 0)
+		record.Add(transformedDetails...)
+		_ = loggingImpl.logger.Handler().Handle(ctx, record)
+
+		return true
+	}
+
+	loggingImpl.logger.Log(ctx, logLevel, message, transformedDetails...)
+
+	return true
+}
+
+// Log how many times a message was repeated, after OptionDuplicateConsecutive or OptionDuplicateWindow collapsed it.
+// The context of the first message gives the summary its context attributes and record attributes.
+func (loggingImpl *BasicLogging) logDuplicateSummary(run *duplicateRun) {
+	details := transformDetails(run.details...)
+	details = append(details, messenger.MessageDuration{Value: run.last.Sub(run.first).Nanoseconds()})
+	details = loggingImpl.idLevelRanges.appendLevel(run.messageNumber, details)
+	details, _ = loggingImpl.placeholders.appendText(run.messageNumber, run.details, loggingImpl.attrs, details)
+	message, logLevel, newDetails := loggingImpl.messenger.NewSlogLevel(
+		run.messageNumber,
+		loggingImpl.withSinkMessageFields(details)...,
+	)
+	logLevel = messageLevel(details, logLevel)
+	repeated := fmt.Sprintf("repeated %d times", run.count-1)
+	if run.count == 2 { //nolint:mnd
+		repeated = "repeated once"
+	}
+
+	if message == "" {
+		message = repeated
+	} else {
+		message = fmt.Sprintf("%s (%s)", message, repeated)
+	}
+
+	newDetails = append(
+		newDetails,
+		slog.Int("repeated", run.count-1),
+		slog.Time("first", run.first),
+		slog.Time("last", run.last),
+	)
+	loggingImpl.log(run.ctx, logLevel, message, newDetails)
+}
+
+// Log a diagnostic about a message, e.g. a placeholder of its template that cannot be resolved.
+func (loggingImpl *BasicLogging) logDiagnostic(
+	ctx context.Context,
+	diagnosticID int,
+	messageNumber int,
+	text string,
+	attrs ...slog.Attr,
+) {
+	ctx, ok := loggingImpl.filter(ctx, diagnosticID, []interface{}{messageNumber, text})
+	if !ok {
+		return
+	}
+
+	levelDetails := loggingImpl.idLevelRanges.appendLevel(diagnosticID, []interface{}{messenger.MessageText{Value: text}})
+	message, logLevel, details := loggingImpl.messenger.NewSlogLevel(
+		diagnosticID,
+		loggingImpl.withSinkMessageFields(levelDetails)...,
+	)
+	logLevel = messageLevel(levelDetails, logLevel)
+
+	details = append(details, slog.Int("messageNumber", messageNumber))
+	for _, attr := range attrs {
+		details = append(details, attr)
+	}
+
+	loggingImpl.log(ctx, logLevel, message, details)
+}
+
+// Log how many messages were suppressed by OptionSampling in an interval.
+func (loggingImpl *BasicLogging) logSamplingSummary(messageNumber int, suppressed int, interval time.Duration) {
+	levelDetails := loggingImpl.idLevelRanges.appendLevel(messageNumber, []interface{}{
+		messenger.MessageText{Value: fmt.Sprintf("%d messages suppressed in %s", suppressed, interval)},
+		messenger.MessageDuration{Value: interval.Nanoseconds()},
+	})
+	message, logLevel, details := loggingImpl.messenger.NewSlogLevel(
+		messageNumber,
+		loggingImpl.withSinkMessageFields(levelDetails)...,
+	)
+	logLevel = messageLevel(levelDetails, logLevel)
+	details = append(details, slog.Int("suppressed", suppressed))
+	loggingImpl.log(loggingImpl.Ctx, logLevel, message, details)
+}
+
+/*
+Count the frame of logContext() in the caller skip given to the messenger.
+A caller skip in the details, from OptionCallerSkip, replaces the caller skip of the logger.
+*/
+func (loggingImpl *BasicLogging) withCallerSkip(details []interface{}) []interface{} {
+	callerSkip := loggingImpl.callerSkip
+
+	for _, detail := range details {
+		if typedDetail, ok := detail.(messenger.OptionCallerSkip); ok {
+			callerSkip = typedDetail.Value
+		}
+	}
+
+	if callerSkip <= 0 {
+		return details
+	}
+
+	return append(slices.Clip(details), messenger.OptionCallerSkip{Value: callerSkip + 1})
+}
+
+/*
+Add the message fields of the sinks to the details given to the messenger.
+With Sink.MessageFields, the messenger creates the message fields of every sink, regardless of SENZING_MESSAGE_FIELDS.
+*/
+func (loggingImpl *BasicLogging) withSinkMessageFields(details []interface{}) []interface{} {
+	if loggingImpl.sinkMessageFields == nil {
+		return details
+	}
+
+	return append(slices.Clip(details), messenger.OptionMessageFields{Value: loggingImpl.sinkMessageFields})
 }
 
 // ----------------------------------------------------------------------------
-// Private methods
+// Private functions
 // ----------------------------------------------------------------------------
 
+// Attributes as a message detail.  Values keep their types; groups become nested maps.
+func attrsAsDetails(attrs []slog.Attr) []interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	return []interface{}{attrsAsMap(attrs)}
+}
+
+// Attributes as a map of key to value.  Groups become nested maps.
+func attrsAsMap(attrs []slog.Attr) map[string]interface{} {
+	result := make(map[string]interface{}, len(attrs))
+
+	for _, attr := range attrs {
+		value := attr.Value.Resolve()
+		if value.Kind() == slog.KindGroup {
+			result[attr.Key] = attrsAsMap(value.Group())
+
+			continue
+		}
+
+		result[attr.Key] = value.Any()
+	}
+
+	return result
+}
+
+// Nest attributes within groups.
+func groupAttrs(groups []string, attrs []slog.Attr) slog.Attr {
+	args := make([]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		args = append(args, attr)
+	}
+
+	if len(groups) == 0 {
+		return slog.Group("", args...)
+	}
+
+	result := slog.Group(groups[len(groups)-1], args...)
+	for index := len(groups) - 2; index >= 0; index-- {
+		result = slog.Group(groups[index], result)
+	}
+
+	return result
+}
+
+// Prefix the attribute key with group names, e.g. "group.key".
+func qualifyAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 {
+		return attr
+	}
+
+	return slog.Attr{
+		Key:   strings.Join(groups, ".") + "." + attr.Key,
+		Value: attr.Value,
+	}
+}
+
 func transformDetails(details ...interface{}) []interface{} {
 	result := []interface{}{}
 
@@ -0,0 +1,153 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+type contextKey string
+
+const (
+	requestIDKey contextKey = "requestID"
+	tenantIDKey  contextKey = "tenantID"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface methods
+// ----------------------------------------------------------------------------
+
+func TestBasicLogging_IsContext(test *testing.T) {
+	test.Parallel()
+
+	ctx := context.Background()
+	logger, err := logging.New(getOptionLogLevel(logging.LevelWarnName))
+	require.NoError(test, err)
+	assert.False(test, logger.(logging.ContextLogging).IsContext(ctx, logging.LevelInfoName))
+	assert.True(test, logger.(logging.ContextLogging).IsContext(ctx, logging.LevelWarnName))
+	assert.False(test, logger.(logging.ContextLogging).IsContext(ctx, badLogLevelName))
+}
+
+func TestBasicLogging_LogContext(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		getOptionContextExtractors(),
+	)
+	require.NoError(test, err)
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "request-1")
+	logger.(logging.ContextLogging).LogContext(ctx, 2001)
+	assert.JSONEq(test, `{"level":"INFO","id":"2001","requestID":"request-1"}`, outputString.String())
+}
+
+func TestBasicLogging_LogContext_location(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		logging.OptionCallerSkip{Value: 3},
+		logging.OptionMessageFields{Value: []string{"id", "location"}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	logger.(logging.ContextLogging).LogContext(context.Background(), 2001)
+	expected := `\{"level":"INFO","id":"2001",` +
+		`"location":"In TestBasicLogging_LogContext_location\(\) at logging_context_test.go:\d+"\}\n`
+	assert.Regexp(test, "^"+expected+expected+"$", outputString.String())
+}
+
+func TestBasicLogging_LogContext_noValues(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		getOptionContextExtractors(),
+	)
+	require.NoError(test, err)
+	logger.(logging.ContextLogging).LogContext(context.Background(), 2001)
+	assert.JSONEq(test, `{"level":"INFO","id":"2001"}`, outputString.String())
+}
+
+func TestBasicLogging_LogContext_multipleExtractors(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		optionOutput(outputString),
+		getOptionTimeHidden(),
+		getOptionContextExtractors(),
+		logging.OptionContextExtractor{Value: logging.ContextValueExtractor("tenantID", tenantIDKey)},
+	)
+	require.NoError(test, err)
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "request-2")
+	ctx = context.WithValue(ctx, tenantIDKey, 42)
+	logger.(logging.ContextLogging).LogContext(ctx, 3001)
+	assert.JSONEq(
+		test,
+		`{"level":"WARN","id":"3001","requestID":"request-2","tenantID":42}`,
+		outputString.String(),
+	)
+}
+
+func TestBasicLogging_NewErrorContext(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		getOptionContextExtractors(),
+		logging.OptionMessageFields{Value: []string{"id", "details"}},
+	)
+	require.NoError(test, err)
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "request-3")
+	err = logger.(logging.ContextLogging).NewErrorContext(ctx, 4001, "A bad thing")
+	require.Error(test, err)
+	assert.JSONEq(
+		test,
+		`{"id":"4001","details":[{"position":1,"type":"string","value":"A bad thing"},{"position":2,"type":"map[string]interface {}",`+
+			`"value":"map[string]interface {}{\"requestID\":\"request-3\"}","valueRaw":{"requestID":"request-3"}}]}`,
+		err.Error(),
+	)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_ContextValueExtractor(test *testing.T) {
+	test.Parallel()
+
+	extractor := logging.ContextValueExtractor("requestID", requestIDKey)
+	assert.Empty(test, extractor(nil)) //nolint:staticcheck
+	assert.Empty(test, extractor(context.Background()))
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "request-4")
+	assert.Equal(test, []slog.Attr{slog.Any("requestID", "request-4")}, extractor(ctx))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func getOptionContextExtractors() logging.OptionContextExtractors {
+	return logging.OptionContextExtractors{
+		Value: []logging.ContextExtractor{
+			logging.ContextValueExtractor("requestID", requestIDKey),
+		},
+	}
+}
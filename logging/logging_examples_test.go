@@ -1,7 +1,9 @@
 package logging_test
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/senzing-garage/go-logging/logging"
 )
@@ -250,3 +252,25 @@ func ExampleBasicLogging_Log_newSenzingLogger() {
 	logger.Log(2001, "Bob", "Jane") // Note that 2000's are INFO messages.
 	// Output:
 }
+
+func ExampleBasicLogging_LogContext() {
+	// For more information, visit https://github.com/senzing-garage/go-logging/blob/main/logging/logging_examples_test.go
+	type contextKey string
+
+	requestIDKey := contextKey("requestID")
+	loggerOptions := []interface{}{
+		logging.OptionContextExtractor{Value: logging.ContextValueExtractor("requestID", requestIDKey)},
+		logging.OptionOutput{Value: os.Stdout},
+		logging.OptionTimeHidden{Value: true},
+	}
+
+	logger, err := logging.New(loggerOptions...)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "request-1")
+	logger.(logging.ContextLogging).LogContext(ctx, 2001)
+	// Output:
+	// {"level":"INFO","id":"2001","requestID":"request-1"}
+}
@@ -542,6 +542,19 @@ func TestLogging_NewSenzingLogger(test *testing.T) {
 	}
 }
 
+func TestLogging_New_extensionInterfaces(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New()
+	require.NoError(test, err)
+	assert.Implements(test, (*logging.AttrLogging)(nil), logger)
+	assert.Implements(test, (*logging.ContextLogging)(nil), logger)
+	assert.Implements(test, (*logging.FilterLogging)(nil), logger)
+	assert.Implements(test, (*logging.LifecycleLogging)(nil), logger)
+	assert.Implements(test, (*logging.SinkLogging)(nil), logger)
+	assert.Implements(test, (*logging.StrictLogging)(nil), logger)
+}
+
 // ----------------------------------------------------------------------------
 // Test private method functions
 // ----------------------------------------------------------------------------
@@ -0,0 +1,130 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface methods
+// ----------------------------------------------------------------------------
+
+func TestBasicLogging_GetLogLevel_fromOption(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New(getOptionLogLevel(logging.LevelWarnName))
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelWarnName, logger.GetLogLevel())
+}
+
+func TestBasicLogging_With(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	jobLogger := logger.(logging.AttrLogging).With("component", "loader", slog.Int("jobID", 17))
+	jobLogger.Log(2001)
+	logger.Log(2002)
+	assert.Equal(
+		test,
+		`{"level":"INFO","component":"loader","jobID":17,"id":"2001"}`+"\n"+`{"level":"INFO","id":"2002"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestBasicLogging_With_chained(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).With("dataSource", "CUSTOMERS").With("jobID", 18).Log(2001)
+	assert.JSONEq(
+		test,
+		`{"level":"INFO","dataSource":"CUSTOMERS","jobID":18,"id":"2001"}`,
+		outputString.String(),
+	)
+}
+
+func TestBasicLogging_With_noDetails(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New()
+	require.NoError(test, err)
+	assert.Same(test, logger, logger.(logging.AttrLogging).With())
+	assert.Same(test, logger, logger.(logging.AttrLogging).WithGroup(""))
+}
+
+func TestBasicLogging_With_sharesLogLevel(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+
+	jobLogger := logger.(logging.AttrLogging).With("jobID", 19)
+	err = logger.SetLogLevel(logging.LevelWarnName)
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelWarnName, jobLogger.GetLogLevel())
+	assert.False(test, jobLogger.IsInfo())
+	jobLogger.Log(2001)
+	assert.Empty(test, outputString.String())
+}
+
+func TestBasicLogging_With_JSON(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New(logging.OptionMessageFields{Value: []string{"id", "details"}})
+	require.NoError(test, err)
+
+	actual := logger.(logging.AttrLogging).WithGroup("job").With("jobID", "job-20").JSON(2001)
+	assert.JSONEq(
+		test,
+		`{"id":"2001","details":[{"position":1,"type":"map[string]interface {}",`+
+			`"value":"map[string]interface {}{\"job.jobID\":\"job-20\"}","valueRaw":{"job.jobID":"job-20"}}]}`,
+		actual,
+	)
+}
+
+func TestBasicLogging_With_NewError(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.New(logging.OptionMessageFields{Value: []string{"id", "details"}})
+	require.NoError(test, err)
+
+	err = logger.(logging.AttrLogging).
+		With("jobID", "job-21", "attempt", 2, "retry", true, slog.Group("record", "recordID", 7)).
+		NewError(4001, "A bad thing")
+	require.Error(test, err)
+
+	record, err := logging.ParseRecord([]byte(err.Error()))
+	require.NoError(test, err)
+	require.Len(test, record.Details, 2)
+	assert.Equal(test, map[string]interface{}{
+		"jobID":   "job-21",
+		"attempt": float64(2),
+		"retry":   true,
+		"record":  map[string]interface{}{"recordID": float64(7)},
+	}, record.Details[1].ValueRaw)
+}
+
+func TestBasicLogging_WithGroup(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(optionOutput(outputString), getOptionTimeHidden())
+	require.NoError(test, err)
+	logger.(logging.AttrLogging).WithGroup("job").With("jobID", 22).WithGroup("record").With("recordID", "R1").Log(2001)
+	assert.Equal(
+		test,
+		`{"level":"INFO","job":{"jobID":22},"job":{"record":{"recordID":"R1"}},"id":"2001"}`+"\n",
+		outputString.String(),
+	)
+}
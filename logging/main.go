@@ -6,10 +6,13 @@ For examples of use, see https://github.com/senzing-garage/go-logging/blob/main/
 package logging
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	stdslog "log/slog"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/senzing-garage/go-helpers/wraperror"
@@ -23,6 +26,8 @@ import (
 
 // The Logging interface has methods for creating different
 // representations of a message.
+// A Logging from New() also implements AttrLogging, ContextLogging, FilterLogging,
+// LifecycleLogging, SinkLogging, and StrictLogging; use a type assertion to find them.
 type Logging interface {
 	GetLogLevel() string                                      // Get the current level of logging.
 	Is(logLevelName string) bool                              // Returns true if logLevelName message will be logged.
@@ -39,20 +44,203 @@ type Logging interface {
 	SetLogLevel(logLevelName string) error                    // Set the level of logging.
 }
 
+// The AttrLogging interface has methods for a Logging that adds attributes to each message.
+// The methods return an AttrLogging, so calls can be chained, e.g. WithGroup("job").With("jobID", 17).
+type AttrLogging interface {
+	Logging
+	With(details ...interface{}) AttrLogging // Return an AttrLogging that adds attributes to each message.
+	WithGroup(name string) AttrLogging       // Return an AttrLogging that qualifies attributes of later With() calls.
+}
+
+// The ContextLogging interface has methods for logging with a context.Context.
+type ContextLogging interface {
+	IsContext(ctx context.Context, logLevelName string) bool // Returns true if logLevelName message will be logged.
+	LogContext(
+		ctx context.Context,
+		messageNumber int,
+		details ...interface{},
+	) // Log the message using the context.
+	NewErrorContext(
+		ctx context.Context,
+		messageNumber int,
+		details ...interface{},
+	) error // Return an error object with the message using the context.
+}
+
+// The FilterLogging interface has methods for message-number filters.
+type FilterLogging interface {
+	IsEnabled(messageNumber int) bool      // Returns true if the message will be logged.
+	SetIDFilters(filters []IDFilter) error // Replace the IDFilters.
+}
+
+// The LifecycleLogging interface has methods for writing queued records and releasing outputs.
+type LifecycleLogging interface {
+	Close() error                    // Write queued records, then flush and close the outputs.
+	DroppedRecords() uint64          // The number of records dropped by the async overflow policy.
+	Flush(ctx context.Context) error // Wait until queued records are written, then flush the outputs.
+}
+
+// The SinkLogging interface has methods for the levels of sinks.
+type SinkLogging interface {
+	GetSinkLogLevel(name string) (string, error)            // Get the current level of logging for a sink.
+	SetSinkLogLevel(name string, logLevelName string) error // Set the level of logging for a sink.
+}
+
+// The StrictLogging interface has methods for OptionStrict.
+type StrictLogging interface {
+	TemplateViolations() uint64 // The number of messages not matching their template, with OptionStrict.
+}
+
+// ----------------------------------------------------------------------------
+// Types - function
+// ----------------------------------------------------------------------------
+
+// A ContextExtractor returns attributes found in a context.Context.
+// The attributes are added to every record logged with that context.
+type ContextExtractor func(ctx context.Context) []slog.Attr
+
+// A HandlerFactory creates the slog.Handler that writes log records.
+// The leveler reflects SetLogLevel() and the handlerOptions are the result of SlogHandlerOptions().
+type HandlerFactory func(leveler slog.Leveler, handlerOptions *slog.HandlerOptions) slog.Handler
+
+// A StdlibHandlerFactory creates the log/slog Handler that writes log records.
+// The leveler reflects SetLogLevel() and the handlerOptions are the result of StdlibSlogHandlerOptions().
+type StdlibHandlerFactory func(leveler stdslog.Leveler, handlerOptions *stdslog.HandlerOptions) stdslog.Handler
+
 // ----------------------------------------------------------------------------
 // Types - struct
 // ----------------------------------------------------------------------------
 
+/*
+A CustomLevel is a named log level added with RegisterLevel().
+Once registered, the Name may be used wherever a log level name is accepted,
+e.g. SetLogLevel(), Is(), OptionLogLevel, and IDLevelRange.
+
+Fields
+  - Always: If true, records at this level are logged regardless of log levels.
+  - Name: The name of the level, e.g. "NOTICE".
+  - Value: The level as an integer, e.g. 2 is between INFO (0) and WARN (4).
+*/
+type CustomLevel struct {
+	Always bool
+	Name   string
+	Value  int
+}
+
 type ExtractedValues struct {
-	callerSkip          int
-	componentIdentifier int
-	idMessages          map[int]string
-	idStatuses          map[int]string
-	logLevel            string
-	messageIDTemplate   string
-	messageFields       []string
-	output              io.Writer
-	messengerOptions    []interface{}
+	async                   bool
+	asyncDropBelowLevel     string
+	asyncOverflow           string
+	asyncQueueSize          int
+	asyncSyncLevel          string
+	callerSkip              int
+	componentIdentifier     int
+	contextExtractors       []ContextExtractor
+	duplicateConsecutive    bool
+	duplicateWindow         time.Duration
+	exitCode                int
+	exitFunc                func(code int)
+	fatalAction             string
+	format                  string
+	handlerFactory          HandlerFactory
+	idFilters               []IDFilter
+	idLevelRanges           []IDLevelRange
+	idDocumentationURLs     map[int]string
+	idLevels                map[int]string
+	idMessages              map[int]string
+	idOutOfRangeLevel       string
+	idOutOfRangePolicy      string
+	idRemediations          map[int]string
+	idStatuses              map[int]string
+	locale                  string
+	localizedCatalogs       LocalizedCatalogs
+	logLevel                string
+	messageIDTemplate       string
+	messageFields           []string
+	output                  io.Writer
+	messengerOptions        []interface{}
+	panicAction             string
+	placeholderDiagnosticID int
+	placeholders            bool
+	samplings               []Sampling
+	sinkMessageFields       []string
+	sinks                   []Sink
+	strict                  bool
+	templateDiagnosticID    int
+}
+
+/*
+An IDFilter enables or suppresses messages having message numbers in a range, regardless of the log level.
+When ranges overlap, the narrowest range is used.
+
+Fields
+  - Enabled: If true, the messages are always logged.  If false, the messages are never logged.
+  - FirstMessageNumber: The first message number of the range.
+  - LastMessageNumber: The last message number of the range.  For a single message number, use FirstMessageNumber.
+*/
+type IDFilter struct {
+	Enabled            bool
+	FirstMessageNumber int
+	LastMessageNumber  int
+}
+
+/*
+An IDLevelRange sets the level of messages having message numbers in a range.
+Used with OptionIDLevelRanges.
+
+Fields
+  - FirstMessageNumber: The first message number of the range.
+  - LastMessageNumber: The last message number of the range.
+  - LogLevel: The level of the messages, e.g. "INFO".
+*/
+type IDLevelRange struct {
+	FirstMessageNumber int
+	LastMessageNumber  int
+	LogLevel           string
+}
+
+/*
+A Sampling limits how often messages having message numbers in a range are logged.
+Each message number is counted separately.
+In each Interval, the first Initial messages are logged, then every Thereafter-th message.
+At the end of an Interval in which messages were suppressed, a summary message is logged
+with the same message number and a "suppressed" count.
+FATAL and PANIC messages are never sampled, so OptionFatalAction and OptionPanicAction always apply.
+
+Fields
+  - FirstMessageNumber: The first message number of the range.
+  - Initial: The number of messages logged at the start of each Interval.
+  - Interval: The length of time over which messages are counted.  Default: 1 second.
+  - LastMessageNumber: The last message number of the range.  For a single message number, use FirstMessageNumber.
+  - Thereafter: After Initial messages, every Thereafter-th message is logged.  If 0, no more messages are logged.
+*/
+type Sampling struct {
+	FirstMessageNumber int
+	Initial            int
+	Interval           time.Duration
+	LastMessageNumber  int
+	Thereafter         int
+}
+
+/*
+A Sink is one destination of log records.
+Each sink has its own log level, format, and message fields.
+
+Fields
+  - Format: One of AllFormats.  Default: FormatJSON.
+  - Handler: An slog.Handler which writes the records.  If set, Format and Output are ignored.
+  - LogLevel: The initial level of logging for the sink.  Default: the level of the logger, following SetLogLevel().
+  - MessageFields: The message fields written to the sink.  Default: the message fields of the logger.
+  - Name: Used with GetSinkLogLevel() and SetSinkLogLevel().
+  - Output: Where the records are written.  Default: os.Stderr.
+*/
+type Sink struct {
+	Format        string
+	Handler       slog.Handler
+	LogLevel      string
+	MessageFields []string
+	Name          string
+	Output        io.Writer
 }
 
 // --- Override values when creating messages ---------------------------------
@@ -99,6 +287,38 @@ type MessageTime struct {
 
 // --- Options for New() ------------------------------------------------------
 
+// Write records using a background goroutine.  See OptionAsyncXxxx.
+type OptionAsync struct {
+	Value bool
+}
+
+// With AsyncOverflowDropBelowLevel, records below this level are dropped.  Default: "WARN".
+type OptionAsyncDropBelowLevel struct {
+	Value string
+}
+
+// What happens when the async queue is full.  One of the AsyncOverflowXxxx values.  Default: AsyncOverflowBlock.
+type OptionAsyncOverflow struct {
+	Value string
+}
+
+// The number of records the async queue holds.  Default: 1024.
+type OptionAsyncQueueSize struct {
+	Value int
+}
+
+// Records at or above this level bypass the async queue and are written synchronously,
+// e.g. LevelFatalName.  Default: none.
+type OptionAsyncSyncLevel struct {
+	Value string
+}
+
+// Use the message templates, statuses, and level overrides of a Catalog.
+// Later OptionIDMessages and OptionIDStatuses replace those of the Catalog.
+type OptionCatalog struct {
+	Value *Catalog
+}
+
 type OptionCallerSkip struct {
 	Value int
 }
@@ -106,6 +326,70 @@ type OptionCallerSkip struct {
 type OptionComponentID struct {
 	Value int
 }
+
+type OptionContextExtractor struct {
+	Value ContextExtractor
+}
+
+type OptionContextExtractors struct {
+	Value []ContextExtractor
+}
+
+// Collapse identical messages logged one after another into one message and a summary.
+// FATAL and PANIC messages are never collapsed.
+type OptionDuplicateConsecutive struct {
+	Value bool
+}
+
+// Collapse identical messages logged within this time of the first into one message and a summary.
+// FATAL and PANIC messages are never collapsed.
+type OptionDuplicateWindow struct {
+	Value time.Duration
+}
+
+// The exit code used by ActionExit.  Default: 1.
+type OptionExitCode struct {
+	Value int
+}
+
+// The function ActionExit calls to end the process.  Default: os.Exit.  Tests may replace it.
+type OptionExitFunc struct {
+	Value func(code int)
+}
+
+// What happens after a FATAL record is logged.  One of the ActionXxxx values.  Default: ActionLog.
+// The logger package checks its OptionFatalAction the same way,
+// but defaults to ActionExit, as Fatal() always exited.
+type OptionFatalAction struct {
+	Value string
+}
+
+type OptionFormat struct {
+	Value string
+}
+
+type OptionHandler struct {
+	Value slog.Handler
+}
+
+type OptionHandlerFactory struct {
+	Value HandlerFactory
+}
+
+type OptionIDFilter struct {
+	Value IDFilter
+}
+
+type OptionIDFilters struct {
+	Value []IDFilter
+}
+
+// The levels of message numbers, replacing IDLevelRangesAsString for this logger.
+// Ranges must not overlap or leave gaps between them.
+type OptionIDLevelRanges struct {
+	Value []IDLevelRange
+}
+
 type OptionIDMessages struct {
 	Value map[int]string
 }
@@ -114,6 +398,32 @@ type OptionIDStatuses struct {
 	Value map[int]string
 }
 
+// With IDOutOfRangeLevel, the level of message numbers outside all OptionIDLevelRanges.
+// Without OptionIDLevelRanges, the ranges are those of IDLevelRangesAsString, ending at message number 6999.
+// Default: "ERROR", or "PANIC" without OptionIDLevelRanges, as message numbers of 7000 and above have always been.
+type OptionIDOutOfRangeLevel struct {
+	Value string
+}
+
+// What happens to message numbers outside all OptionIDLevelRanges.  One of the IDOutOfRangeXxxx values.
+// Without OptionIDLevelRanges, the ranges are those of IDLevelRangesAsString, ending at message number 6999.
+// Default: IDOutOfRangeLevel.
+type OptionIDOutOfRangePolicy struct {
+	Value string
+}
+
+// The locale of the messages of OptionLocalizedCatalogs, e.g. "fr-CA".
+// Default: from LC_ALL, LC_MESSAGES, or LANG, otherwise English.
+type OptionLocale struct {
+	Value string
+}
+
+// Use the Catalog of the OptionLocale, with fallback to less specific locales and English.
+// Replaces OptionCatalog, OptionIDMessages, and OptionIDStatuses.
+type OptionLocalizedCatalogs struct {
+	Value LocalizedCatalogs
+}
+
 type OptionLogLevel struct {
 	Value string
 }
@@ -130,6 +440,63 @@ type OptionMessageIDTemplate struct {
 	Value string
 }
 
+// What happens after a PANIC record is logged.  One of the ActionXxxx values.  Default: ActionLog.
+// The logger package checks its OptionPanicAction the same way,
+// but defaults to ActionPanic, as Panic() always panicked.
+type OptionPanicAction struct {
+	Value string
+}
+
+// With OptionPlaceholders, the message number of the diagnostic logged when a placeholder cannot be resolved.
+// Default: PlaceholderDiagnosticID.  Suppress the diagnostic with an IDFilter.
+// New() fails if OptionIDMessages defines a message with this number.
+type OptionPlaceholderDiagnosticID struct {
+	Value int
+}
+
+// Format message templates having named placeholders, e.g. "{entityID}", instead of with fmt verbs.
+// Default: false, so braces in templates are literal text, as before.
+type OptionPlaceholders struct {
+	Value bool
+}
+
+type OptionSampling struct {
+	Value Sampling
+}
+
+type OptionSamplings struct {
+	Value []Sampling
+}
+
+type OptionSink struct {
+	Value Sink
+}
+
+type OptionSinks struct {
+	Value []Sink
+}
+
+type OptionStdlibHandler struct {
+	Value stdslog.Handler
+}
+
+type OptionStdlibHandlerFactory struct {
+	Value StdlibHandlerFactory
+}
+
+// Check message templates: New() fails if a template of OptionIDMessages does not parse,
+// and a diagnostic is logged when the details of a message do not match the fmt verbs of its template.
+type OptionStrict struct {
+	Value bool
+}
+
+// With OptionStrict, the message number of the diagnostic logged when details do not match a template.
+// Default: TemplateDiagnosticID.  Suppress the diagnostic with an IDFilter.
+// With OptionStrict, New() fails if OptionIDMessages defines a message with this number.
+type OptionTemplateDiagnosticID struct {
+	Value int
+}
+
 type OptionTimeHidden struct {
 	Value bool
 }
@@ -165,6 +532,35 @@ const (
 	LevelWarnName  = "WARN"
 )
 
+// Output formats used with OptionFormat.
+const (
+	FormatConsole = "console" // Human-readable, colorized text.  Set NO_COLOR to disable colors.
+	FormatJSON    = "json"    // JSON objects, one per line.  The default.
+	FormatLogfmt  = "logfmt"  // key=value pairs, one record per line.
+	FormatText    = "text"    // Output of slog.TextHandler.
+)
+
+// Actions used with OptionFatalAction and OptionPanicAction.
+const (
+	ActionExit  = "exit"  // The logger is closed, then OptionExitFunc is called with OptionExitCode.
+	ActionLog   = "log"   // The record is logged and the program continues.  The default.
+	ActionPanic = "panic" // The logger is flushed, then panics with the error NewError() returns.
+)
+
+// Overflow policies used with OptionAsyncOverflow.
+const (
+	AsyncOverflowBlock          = "block"            // Log() waits until the queue has room.  The default.
+	AsyncOverflowDropBelowLevel = "drop-below-level" // Records below OptionAsyncDropBelowLevel are dropped, others wait.
+	AsyncOverflowDropNewest     = "drop-newest"      // The record being logged is dropped.
+	AsyncOverflowDropOldest     = "drop-oldest"      // The oldest queued record is dropped.
+)
+
+// Policies used with OptionIDOutOfRangePolicy.
+const (
+	IDOutOfRangeDrop  = "drop"  // Messages are not logged.
+	IDOutOfRangeLevel = "level" // Messages are logged at the OptionIDOutOfRangeLevel.  The default.
+)
+
 // Existing and new log levels used with slog.Level.
 const (
 	LevelDebugSlog = slog.LevelDebug
@@ -177,6 +573,7 @@ const (
 )
 
 const (
+	asyncQueueSize      = 1024
 	componentIdentifier = 9999
 )
 
@@ -232,21 +629,56 @@ var AllMessageFields = []string{ //nolint
 	"details",
 }
 
+// The values usable with OptionFormat.
+var AllFormats = []string{ //nolint
+	FormatConsole,
+	FormatJSON,
+	FormatLogfmt,
+	FormatText,
+}
+
 var errForPackage = errors.New("logging")
 
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
 
+/*
+The ContextValueExtractor function returns a ContextExtractor that adds the value
+stored in a context.Context under contextKey as an attribute named key.
+Contexts without the value contribute nothing.
+
+Input
+  - key: The name of the attribute in the log record.
+  - contextKey: The key used with context.WithValue().
+
+Output
+  - A ContextExtractor usable with OptionContextExtractor.
+*/
+func ContextValueExtractor(key string, contextKey interface{}) ContextExtractor {
+	return func(ctx context.Context) []slog.Attr {
+		if ctx == nil {
+			return nil
+		}
+
+		value := ctx.Value(contextKey)
+		if value == nil {
+			return nil
+		}
+
+		return []slog.Attr{slog.Any(key, value)}
+	}
+}
+
 /*
 The IsValidLogLevelName function checks the logLevelName to verify it is one of
-"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", or "PANIC".
+"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC", or a name added with RegisterLevel().
 
 Input
   - logLevelName: A name to be tested.
 
 Output
-  - boolean: True if name in "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", or "PANIC".
+  - boolean: True if name in "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC", or a registered name.
 */
 func IsValidLogLevelName(logLevelName string) bool {
 	_, ok := TextToLevelMap[logLevelName]
@@ -272,14 +704,25 @@ func New(options ...interface{}) (Logging, error) {
 	)
 
 	extractedValues := &ExtractedValues{
-		callerSkip:          0,
-		componentIdentifier: componentIdentifier,
-		idMessages:          map[int]string{},
-		idStatuses:          map[int]string{},
-		logLevel:            LevelInfoName,
-		messageIDTemplate:   "%d",
-		messengerOptions:    []interface{}{},
-		output:              os.Stderr,
+		asyncDropBelowLevel:     LevelWarnName,
+		asyncOverflow:           AsyncOverflowBlock,
+		asyncQueueSize:          asyncQueueSize,
+		callerSkip:              0,
+		componentIdentifier:     componentIdentifier,
+		exitCode:                1,
+		exitFunc:                os.Exit,
+		fatalAction:             ActionLog,
+		format:                  FormatJSON,
+		idMessages:              map[int]string{},
+		idOutOfRangePolicy:      IDOutOfRangeLevel,
+		idStatuses:              map[int]string{},
+		logLevel:                LevelInfoName,
+		messageIDTemplate:       "%d",
+		messengerOptions:        []interface{}{},
+		output:                  os.Stderr,
+		panicAction:             ActionLog,
+		placeholderDiagnosticID: PlaceholderDiagnosticID,
+		templateDiagnosticID:    TemplateDiagnosticID,
 	}
 	extractFromOptions(extractedValues, options)
 
@@ -297,6 +740,10 @@ func New(options ...interface{}) (Logging, error) {
 		return result, wraperror.Errorf(err, "New")
 	}
 
+	// The messenger sorts its level ranges on first use, which is not safe for concurrent use.
+
+	_, _, _ = messenger.NewSlogLevel(0)
+
 	slogLevel, ok := TextToLevelMap[extractedValues.logLevel]
 	if !ok {
 		return result, wraperror.Errorf(errForPackage, "unknown error level: %s", extractedValues.logLevel)
@@ -307,18 +754,65 @@ func New(options ...interface{}) (Logging, error) {
 
 	// Create logger.
 
-	logger := slog.New(slog.NewJSONHandler(extractedValues.output, SlogHandlerOptions(slogLeveler, options...)))
+	handlerOptions := SlogHandlerOptions(slogLeveler, options...)
+
+	var (
+		handler      slog.Handler
+		sinkLevelers map[string]*sinkLeveler
+	)
+
+	switch {
+	case len(extractedValues.sinks) > 0:
+		handler, sinkLevelers = newSinksHandler(extractedValues, slogLeveler, options)
+	case extractedValues.handlerFactory != nil:
+		handler = extractedValues.handlerFactory(slogLeveler, handlerOptions)
+	default:
+		handler = newFormatHandler(extractedValues.format, extractedValues.output, handlerOptions)
+	}
+
+	lifecycle := newLifecycle(extractedValues, handler)
+	lifecycle.duplicates = newDuplicateFilter(extractedValues)
+	lifecycle.sampler = newSampler(extractedValues.samplings)
+
+	if extractedValues.async {
+		handler, lifecycle.asyncQueue = newAsyncHandler(handler, extractedValues)
+	}
+
+	logger := slog.New(handler)
 
 	// Create LoggingInterface.
 
+	idFilters := &idFilters{}
+
+	err = idFilters.set(extractedValues.idFilters)
+	if err != nil {
+		return result, err
+	}
+
 	loggingImpl := &BasicLogging{
-		logger:    logger,
-		messenger: messenger,
-		leveler:   slogLeveler,
+		actions:             newLevelActions(extractedValues),
+		callerSkip:          extractedValues.callerSkip,
+		contextExtractors:   extractedValues.contextExtractors,
+		idDocumentationURLs: extractedValues.idDocumentationURLs,
+		idFilters:           idFilters,
+		idLevelRanges:       newIDLevelRanges(extractedValues),
+		idRemediations:      extractedValues.idRemediations,
+		logger:              logger,
+		messenger:           messenger,
+		leveler:             slogLeveler,
+		lifecycle:           lifecycle,
+		placeholders:        newPlaceholders(extractedValues),
+		sinkLevelers:        sinkLevelers,
+		sinkMessageFields:   extractedValues.sinkMessageFields,
+		templateChecker:     newTemplateChecker(extractedValues),
 	}
 
 	loggingImpl.initialize()
 
+	if lifecycle.sampler != nil {
+		lifecycle.sampler.summarize = loggingImpl.logSamplingSummary
+	}
+
 	return loggingImpl, nil
 }
 
@@ -329,6 +823,7 @@ specifically for use with senzing-tools.
 Input
   - componentId: See list at https://github.com/senzing-garage/knowledge-base/blob/main/lists/senzing-product-ids.md
   - idMessage: A map of integer to string message templates.
+    May be nil if the options have an OptionCatalog, whose messages replace it.
   - options: Variadic arguments listing the options (usually having type OptionXxxxx) used to configure the logger.
 
 Output
@@ -349,7 +844,26 @@ func NewSenzingLogger(componentID int, idMessages map[int]string, options ...int
 }
 
 /*
-The SlogHandlerOptions function returns a slog handler that includes TRACE, FATAL, and PANIC.
+The RegisterLevel function adds a named log level.
+Register levels during program initialization, before loggers are created;
+it is not safe to call while messages are logged.
+The level is added to LevelToTextMap and TextToLevelMap of this package only;
+the level maps of go-messaging are not changed.
+
+Input
+  - customLevel: The name and value of the level.
+    Neither the name nor the value may already be registered.
+
+Output
+  - error
+*/
+func RegisterLevel(customLevel CustomLevel) error {
+	return registerLevel(customLevel)
+}
+
+/*
+The SlogHandlerOptions function returns a slog handler that includes TRACE, FATAL, PANIC,
+and the levels added with RegisterLevel().
 See: https://go.googlesource.com/exp/+/refs/heads/master/slog/example_custom_levels_test.go
 */
 func SlogHandlerOptions(leveler slog.Leveler, options ...interface{}) *slog.HandlerOptions {
@@ -381,18 +895,13 @@ func SlogHandlerOptions(leveler slog.Leveler, options ...interface{}) *slog.Hand
 
 				switch typedValue := slogAttr.Value.Any().(type) {
 				case string:
-					level = typedValue
+					level = levelNameOfString(typedValue)
 				case slog.Level:
-					level = typedValue.String()
+					level = levelName(typedValue)
 				}
 
-				switch level {
-				case "DEBUG-4":
-					slogAttr.Value = slog.StringValue(LevelTraceName)
-				case "ERROR+4":
-					slogAttr.Value = slog.StringValue(LevelFatalName)
-				case "ERROR+8":
-					slogAttr.Value = slog.StringValue(LevelPanicName)
+				if level != "" {
+					slogAttr.Value = slog.StringValue(level)
 				}
 			}
 
@@ -425,6 +934,8 @@ func SlogHandlerOptions(leveler slog.Leveler, options ...interface{}) *slog.Hand
 // ----------------------------------------------------------------------------
 
 func constructOptions(extractedValues *ExtractedValues) {
+	constructSinkMessageFields(extractedValues)
+
 	extractedValues.messengerOptions = append(
 		extractedValues.messengerOptions,
 		messenger.OptionIDMessages{Value: extractedValues.idMessages},
@@ -453,18 +964,73 @@ func constructOptions(extractedValues *ExtractedValues) {
 	}
 }
 
+// Use the messages, statuses, levels, remediations, and documentation URLs of a Catalog.
+func extractCatalog(extracted *ExtractedValues, catalog *Catalog) {
+	extracted.idDocumentationURLs = catalog.IDDocumentationURLs()
+	extracted.idLevels = catalog.IDLevels()
+	extracted.idMessages = catalog.IDMessages()
+	extracted.idRemediations = catalog.IDRemediations()
+	extracted.idStatuses = catalog.IDStatuses()
+}
+
 func extractFromOptions(extracted *ExtractedValues, options []interface{}) {
 	for _, value := range options {
 		switch typedValue := value.(type) {
+		case OptionAsync:
+			extracted.async = typedValue.Value
+		case OptionAsyncDropBelowLevel:
+			extracted.asyncDropBelowLevel = typedValue.Value
+		case OptionAsyncOverflow:
+			extracted.asyncOverflow = typedValue.Value
+		case OptionAsyncQueueSize:
+			extracted.asyncQueueSize = typedValue.Value
+		case OptionAsyncSyncLevel:
+			extracted.asyncSyncLevel = typedValue.Value
+		case OptionCatalog:
+			extractCatalog(extracted, typedValue.Value)
 		case OptionCallerSkip:
 			extracted.callerSkip = typedValue.Value
 		case OptionComponentID:
 			extracted.componentIdentifier = typedValue.Value
 			extracted.messageIDTemplate = fmt.Sprintf("senzing-%04d", extracted.componentIdentifier) + "%04d"
+		case OptionContextExtractor:
+			extracted.contextExtractors = append(extracted.contextExtractors, typedValue.Value)
+		case OptionContextExtractors:
+			extracted.contextExtractors = append(extracted.contextExtractors, typedValue.Value...)
+		case OptionDuplicateConsecutive:
+			extracted.duplicateConsecutive = typedValue.Value
+		case OptionDuplicateWindow:
+			extracted.duplicateWindow = typedValue.Value
+		case OptionExitCode:
+			extracted.exitCode = typedValue.Value
+		case OptionExitFunc:
+			extracted.exitFunc = typedValue.Value
+		case OptionFatalAction:
+			extracted.fatalAction = typedValue.Value
+		case OptionFormat:
+			extracted.format = typedValue.Value
+		case OptionHandler:
+			extracted.handlerFactory = handlerFactory(typedValue.Value)
+		case OptionHandlerFactory:
+			extracted.handlerFactory = typedValue.Value
+		case OptionIDFilter:
+			extracted.idFilters = append(extracted.idFilters, typedValue.Value)
+		case OptionIDFilters:
+			extracted.idFilters = append(extracted.idFilters, typedValue.Value...)
+		case OptionIDLevelRanges:
+			extracted.idLevelRanges = typedValue.Value
 		case OptionIDMessages:
 			extracted.idMessages = typedValue.Value
 		case OptionIDStatuses:
 			extracted.idStatuses = typedValue.Value
+		case OptionIDOutOfRangeLevel:
+			extracted.idOutOfRangeLevel = typedValue.Value
+		case OptionIDOutOfRangePolicy:
+			extracted.idOutOfRangePolicy = typedValue.Value
+		case OptionLocale:
+			extracted.locale = typedValue.Value
+		case OptionLocalizedCatalogs:
+			extracted.localizedCatalogs = typedValue.Value
 		case OptionLogLevel:
 			extracted.logLevel = typedValue.Value
 		case OptionMessageField:
@@ -477,7 +1043,55 @@ func extractFromOptions(extracted *ExtractedValues, options []interface{}) {
 			extracted.messageIDTemplate = typedValue.Value
 		case OptionOutput:
 			extracted.output = typedValue.Value
+		case OptionPanicAction:
+			extracted.panicAction = typedValue.Value
+		case OptionPlaceholderDiagnosticID:
+			extracted.placeholderDiagnosticID = typedValue.Value
+		case OptionPlaceholders:
+			extracted.placeholders = typedValue.Value
+		case OptionSampling:
+			extracted.samplings = append(extracted.samplings, typedValue.Value)
+		case OptionSamplings:
+			extracted.samplings = append(extracted.samplings, typedValue.Value...)
+		case OptionSink:
+			extracted.sinks = append(extracted.sinks, typedValue.Value)
+		case OptionSinks:
+			extracted.sinks = append(extracted.sinks, typedValue.Value...)
+		case OptionStdlibHandler:
+			extracted.handlerFactory = handlerFactory(&stdlibHandler{handler: typedValue.Value})
+		case OptionStdlibHandlerFactory:
+			extracted.handlerFactory = stdlibHandlerFactory(typedValue.Value, options)
+		case OptionStrict:
+			extracted.strict = typedValue.Value
+		case OptionTemplateDiagnosticID:
+			extracted.templateDiagnosticID = typedValue.Value
+		}
+	}
+
+	if extracted.localizedCatalogs != nil {
+		locale := extracted.locale
+		if locale == "" {
+			locale = environmentLocale()
 		}
+
+		extractCatalog(extracted, extracted.localizedCatalogs.Catalog(locale))
+	}
+}
+
+// A HandlerFactory for a caller-supplied handler.  The handler honors SetLogLevel() and the ReplaceAttr of handlerOptions.
+func handlerFactory(handler slog.Handler) HandlerFactory {
+	return func(leveler slog.Leveler, handlerOptions *slog.HandlerOptions) slog.Handler {
+		return newLevelHandler(handler, leveler, handlerOptions)
+	}
+}
+
+// A HandlerFactory wrapping a StdlibHandlerFactory.
+func stdlibHandlerFactory(factory StdlibHandlerFactory, options []interface{}) HandlerFactory {
+	return func(leveler slog.Leveler, handlerOptions *slog.HandlerOptions) slog.Handler {
+		_ = handlerOptions
+		stdlibLeveler := stdlibLeveler{leveler: leveler}
+
+		return &stdlibHandler{handler: factory(stdlibLeveler, StdlibSlogHandlerOptions(stdlibLeveler, options...))}
 	}
 }
 
@@ -490,5 +1104,54 @@ func verifyOptions(extractedValues *ExtractedValues) error {
 		)
 	}
 
-	return nil
+	if !slices.Contains(AllFormats, extractedValues.format) {
+		return wraperror.Errorf(errForPackage, "unknown format: %s", extractedValues.format)
+	}
+
+	err := verifyActions(extractedValues)
+	if err != nil {
+		return err
+	}
+
+	err = verifyAsync(extractedValues)
+	if err != nil {
+		return err
+	}
+
+	err = verifyCatalogLevels(extractedValues.idLevels)
+	if err != nil {
+		return err
+	}
+
+	err = verifyDuplicates(extractedValues)
+	if err != nil {
+		return err
+	}
+
+	err = verifyIDFilters(extractedValues.idFilters)
+	if err != nil {
+		return err
+	}
+
+	err = verifyIDLevelRanges(extractedValues)
+	if err != nil {
+		return err
+	}
+
+	err = verifyPlaceholders(extractedValues)
+	if err != nil {
+		return err
+	}
+
+	err = verifySamplings(extractedValues.samplings)
+	if err != nil {
+		return err
+	}
+
+	err = verifySinks(extractedValues.sinks)
+	if err != nil {
+		return err
+	}
+
+	return verifyTemplates(extractedValues)
 }
@@ -0,0 +1,250 @@
+package logging
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// The OTLP ExportLogsServiceRequest is encoded by hand so that this module does not depend on
+// the OpenTelemetry and protobuf modules.
+// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/logs/v1/logs.proto
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The otlpRecord type holds one log record until it is exported.
+type otlpRecord struct {
+	attrs          []slog.Attr
+	body           string
+	observedTime   time.Time
+	severityNumber int
+	severityText   string
+	spanID         []byte
+	time           time.Time
+	traceFlags     byte
+	traceID        []byte
+}
+
+// The otlpResource type holds the values that are the same for every exported record.
+type otlpResource struct {
+	attrs        []slog.Attr
+	scopeName    string
+	scopeVersion string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ----------------------------------------------------------------------------
+// Private functions - protobuf
+// ----------------------------------------------------------------------------
+
+// Encode an ExportLogsServiceRequest.
+func otlpProtobuf(resource *otlpResource, records []otlpRecord) []byte {
+	scope := protobufString(nil, 1, resource.scopeName)
+	scope = protobufString(scope, 2, resource.scopeVersion) //nolint:mnd
+
+	scopeLogs := protobufMessage(nil, 1, scope)
+	for _, record := range records {
+		scopeLogs = protobufMessage(scopeLogs, 2, otlpProtobufRecord(record)) //nolint:mnd
+	}
+
+	resourceMessage := []byte{}
+	for _, attr := range resource.attrs {
+		resourceMessage = protobufMessage(resourceMessage, 1, otlpProtobufKeyValue(attr))
+	}
+
+	resourceLogs := protobufMessage(nil, 1, resourceMessage)
+	resourceLogs = protobufMessage(resourceLogs, 2, scopeLogs) //nolint:mnd
+
+	return protobufMessage(nil, 1, resourceLogs)
+}
+
+// Encode an AnyValue.
+func otlpProtobufAnyValue(value slog.Value) []byte {
+	switch value.Kind() {
+	case slog.KindBool:
+		boolValue := uint64(0)
+		if value.Bool() {
+			boolValue = 1
+		}
+
+		return protobufVarint(nil, 2, boolValue) //nolint:mnd
+	case slog.KindDuration:
+		return protobufVarint(nil, 3, uint64(value.Duration())) //nolint:mnd,gosec
+	case slog.KindFloat64:
+		return protobufFixed64(nil, 4, math.Float64bits(value.Float64())) //nolint:mnd
+	case slog.KindInt64:
+		return protobufVarint(nil, 3, uint64(value.Int64())) //nolint:mnd,gosec
+	case slog.KindUint64:
+		return protobufVarint(nil, 3, value.Uint64()) //nolint:mnd
+	case slog.KindGroup:
+		kvlist := []byte{}
+		for _, attr := range value.Group() {
+			kvlist = protobufMessage(kvlist, 1, otlpProtobufKeyValue(attr))
+		}
+
+		return protobufMessage(nil, 6, kvlist) //nolint:mnd
+	default:
+		return protobufString(nil, 1, valueAsString(value))
+	}
+}
+
+// Encode a KeyValue.
+func otlpProtobufKeyValue(attr slog.Attr) []byte {
+	result := protobufString(nil, 1, attr.Key)
+
+	return protobufMessage(result, 2, otlpProtobufAnyValue(attr.Value.Resolve())) //nolint:mnd
+}
+
+// Encode a LogRecord.
+func otlpProtobufRecord(record otlpRecord) []byte {
+	result := protobufFixed64(nil, 1, uint64(record.time.UnixNano()))        //nolint:gosec
+	result = protobufVarint(result, 2, uint64(record.severityNumber))        //nolint:mnd,gosec
+	result = protobufString(result, 3, record.severityText)                  //nolint:mnd
+	result = protobufMessage(result, 5, protobufString(nil, 1, record.body)) //nolint:mnd
+
+	for _, attr := range record.attrs {
+		result = protobufMessage(result, 6, otlpProtobufKeyValue(attr)) //nolint:mnd
+	}
+
+	if len(record.traceID) > 0 {
+		result = protobufFixed32(result, 8, uint32(record.traceFlags)) //nolint:mnd
+		result = protobufBytes(result, 9, record.traceID)              //nolint:mnd
+		result = protobufBytes(result, 10, record.spanID)              //nolint:mnd
+	}
+
+	return protobufFixed64(result, 11, uint64(record.observedTime.UnixNano())) //nolint:mnd,gosec
+}
+
+func protobufBytes(buffer []byte, fieldNumber int, value []byte) []byte {
+	buffer = protobufTag(buffer, fieldNumber, wireBytes)
+	buffer = binary.AppendUvarint(buffer, uint64(len(value)))
+
+	return append(buffer, value...)
+}
+
+func protobufFixed32(buffer []byte, fieldNumber int, value uint32) []byte {
+	buffer = protobufTag(buffer, fieldNumber, wireFixed32)
+
+	return binary.LittleEndian.AppendUint32(buffer, value)
+}
+
+func protobufFixed64(buffer []byte, fieldNumber int, value uint64) []byte {
+	buffer = protobufTag(buffer, fieldNumber, wireFixed64)
+
+	return binary.LittleEndian.AppendUint64(buffer, value)
+}
+
+func protobufMessage(buffer []byte, fieldNumber int, message []byte) []byte {
+	return protobufBytes(buffer, fieldNumber, message)
+}
+
+func protobufString(buffer []byte, fieldNumber int, value string) []byte {
+	if value == "" {
+		return buffer
+	}
+
+	return protobufBytes(buffer, fieldNumber, []byte(value))
+}
+
+func protobufTag(buffer []byte, fieldNumber int, wireType int) []byte {
+	return binary.AppendUvarint(buffer, uint64(fieldNumber<<3|wireType)) //nolint:mnd,gosec
+}
+
+func protobufVarint(buffer []byte, fieldNumber int, value uint64) []byte {
+	buffer = protobufTag(buffer, fieldNumber, wireVarint)
+
+	return binary.AppendUvarint(buffer, value)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions - JSON
+// ----------------------------------------------------------------------------
+
+// Encode an ExportLogsServiceRequest using the OTLP JSON encoding.
+func otlpJSON(resource *otlpResource, records []otlpRecord) ([]byte, error) {
+	logRecords := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		logRecords = append(logRecords, otlpJSONRecord(record))
+	}
+
+	request := map[string]interface{}{
+		"resourceLogs": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{"attributes": otlpJSONKeyValues(resource.attrs)},
+				"scopeLogs": []interface{}{
+					map[string]interface{}{
+						"scope":      map[string]interface{}{"name": resource.scopeName, "version": resource.scopeVersion},
+						"logRecords": logRecords,
+					},
+				},
+			},
+		},
+	}
+
+	return json.Marshal(request) //nolint:wrapcheck
+}
+
+func otlpJSONAnyValue(value slog.Value) map[string]interface{} {
+	switch value.Kind() {
+	case slog.KindBool:
+		return map[string]interface{}{"boolValue": value.Bool()}
+	case slog.KindDuration:
+		return map[string]interface{}{"intValue": strconv.FormatInt(int64(value.Duration()), 10)}
+	case slog.KindFloat64:
+		return map[string]interface{}{"doubleValue": value.Float64()}
+	case slog.KindInt64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(value.Int64(), 10)}
+	case slog.KindUint64:
+		return map[string]interface{}{"intValue": strconv.FormatUint(value.Uint64(), 10)}
+	case slog.KindGroup:
+		return map[string]interface{}{"kvlistValue": map[string]interface{}{"values": otlpJSONKeyValues(value.Group())}}
+	default:
+		return map[string]interface{}{"stringValue": valueAsString(value)}
+	}
+}
+
+func otlpJSONKeyValues(attrs []slog.Attr) []interface{} {
+	result := make([]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, map[string]interface{}{"key": attr.Key, "value": otlpJSONAnyValue(attr.Value.Resolve())})
+	}
+
+	return result
+}
+
+func otlpJSONRecord(record otlpRecord) map[string]interface{} {
+	result := map[string]interface{}{
+		"timeUnixNano":         strconv.FormatInt(record.time.UnixNano(), 10),
+		"observedTimeUnixNano": strconv.FormatInt(record.observedTime.UnixNano(), 10),
+		"severityNumber":       record.severityNumber,
+		"severityText":         record.severityText,
+		"body":                 map[string]interface{}{"stringValue": record.body},
+		"attributes":           otlpJSONKeyValues(record.attrs),
+	}
+
+	if len(record.traceID) > 0 {
+		result["traceId"] = hex.EncodeToString(record.traceID)
+		result["spanId"] = hex.EncodeToString(record.spanID)
+		result["flags"] = record.traceFlags
+	}
+
+	return result
+}
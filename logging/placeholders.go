@@ -0,0 +1,270 @@
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The placeholders type formats message templates having named placeholders, e.g. "{entityID}",
+when OptionPlaceholders is used.
+Templates without named placeholders are left to go-messaging, which formats them with fmt verbs.
+*/
+type placeholders struct {
+	diagnosticID int
+	templates    map[int]*placeholderTemplate
+}
+
+// The placeholderTemplate type is a message template split into literal text and placeholders.
+type placeholderTemplate struct {
+	parts []placeholderPart
+}
+
+// The placeholderPart type is either literal text or, if name is not empty, a placeholder.
+type placeholderPart struct {
+	literal string
+	name    string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The message number of the diagnostic logged, with OptionPlaceholders, for a placeholder that cannot be resolved.
+const PlaceholderDiagnosticID = 3999
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// A placeholder is a name, optionally qualified by With() groups, or a 1-based detail position.
+var placeholderRegexp = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_.]*|[1-9][0-9]*)\}`)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+Add the text of a message template having named placeholders to the details.
+Returns the details and the names of the placeholders that could not be resolved.
+Details are unchanged if the template has no named placeholders or the details have a MessageText.
+*/
+func (placeholders *placeholders) appendText(
+	messageNumber int,
+	details []interface{},
+	attrs []slog.Attr,
+	transformedDetails []interface{},
+) ([]interface{}, []string) {
+	if placeholders == nil {
+		return transformedDetails, nil
+	}
+
+	template, ok := placeholders.templates[messageNumber]
+	if !ok {
+		return transformedDetails, nil
+	}
+
+	for _, detail := range transformedDetails {
+		if _, ok := detail.(messenger.MessageText); ok {
+			return transformedDetails, nil
+		}
+	}
+
+	text, unresolved := template.format(details, attrs)
+
+	return append(transformedDetails, messenger.MessageText{Value: text}), unresolved
+}
+
+// The template with placeholders replaced by values of the details.
+// Unresolved placeholders are replaced by a marker, e.g. "%!{entityID}(MISSING)", and their names returned.
+func (template *placeholderTemplate) format(details []interface{}, attrs []slog.Attr) (string, []string) {
+	var (
+		result     strings.Builder
+		unresolved []string
+	)
+
+	for _, part := range template.parts {
+		if part.name == "" {
+			result.WriteString(part.literal)
+
+			continue
+		}
+
+		value, ok := placeholderValue(part.name, details, attrs)
+		if !ok {
+			result.WriteString("%!{" + part.name + "}(MISSING)")
+
+			unresolved = append(unresolved, part.name)
+
+			continue
+		}
+
+		result.WriteString(value)
+	}
+
+	return result.String(), unresolved
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The message templates having named placeholders.  Nil if there are none or OptionPlaceholders is not used.
+func newPlaceholders(extractedValues *ExtractedValues) *placeholders {
+	if !extractedValues.placeholders {
+		return nil
+	}
+
+	templates := map[int]*placeholderTemplate{}
+
+	for messageNumber, text := range extractedValues.idMessages {
+		if template := parsePlaceholderTemplate(text); template != nil {
+			templates[messageNumber] = template
+		}
+	}
+
+	if len(templates) == 0 {
+		return nil
+	}
+
+	return &placeholders{
+		diagnosticID: extractedValues.placeholderDiagnosticID,
+		templates:    templates,
+	}
+}
+
+// A template split into literal text and placeholders.  Nil if the template has no placeholders.
+func parsePlaceholderTemplate(text string) *placeholderTemplate {
+	matches := placeholderRegexp.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	result := &placeholderTemplate{}
+	start := 0
+
+	for _, match := range matches {
+		if match[0] > start {
+			result.parts = append(result.parts, placeholderPart{literal: text[start:match[0]]})
+		}
+
+		result.parts = append(result.parts, placeholderPart{name: text[match[2]:match[3]]})
+		start = match[1]
+	}
+
+	if start < len(text) {
+		result.parts = append(result.parts, placeholderPart{literal: text[start:]})
+	}
+
+	return result
+}
+
+/*
+The value of a placeholder, looked up in this order:
+  - A 1-based position, e.g. "{2}", is the detail at that position.
+  - A key of a map[string]string or map[string]interface{} detail.
+  - The key of an slog.Attr detail.
+  - A field of a struct detail, by name regardless of case, or by "json" tag.
+  - The key of an attribute added by With().
+*/
+func placeholderValue(name string, details []interface{}, attrs []slog.Attr) (string, bool) {
+	if position, err := strconv.Atoi(name); err == nil {
+		if position > len(details) {
+			return "", false
+		}
+
+		return fmt.Sprint(details[position-1]), true
+	}
+
+	for _, detail := range details {
+		switch typedDetail := detail.(type) {
+		case map[string]string:
+			if value, ok := typedDetail[name]; ok {
+				return value, true
+			}
+		case map[string]interface{}:
+			if value, ok := typedDetail[name]; ok {
+				return fmt.Sprint(value), true
+			}
+		case slog.Attr:
+			if typedDetail.Key == name {
+				return typedDetail.Value.String(), true
+			}
+		default:
+			if value, ok := structFieldValue(detail, name); ok {
+				return fmt.Sprint(value), true
+			}
+		}
+	}
+
+	for _, attr := range attrs {
+		if attr.Key == name {
+			return attr.Value.String(), true
+		}
+	}
+
+	return "", false
+}
+
+// The value of an exported field of a struct, or pointer to a struct, by name regardless of case, or by "json" tag.
+func structFieldValue(detail interface{}, name string) (interface{}, bool) {
+	value := reflect.ValueOf(detail)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, false
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	valueType := value.Type()
+
+	for index := range valueType.NumField() {
+		field := valueType.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if strings.EqualFold(field.Name, name) || jsonName == name {
+			return value.Field(index).Interface(), true
+		}
+	}
+
+	return nil, false
+}
+
+func verifyPlaceholders(extractedValues *ExtractedValues) error {
+	if extractedValues.placeholderDiagnosticID < 0 {
+		return wraperror.Errorf(
+			errForPackage,
+			"placeholder diagnostic message number must not be negative: %d",
+			extractedValues.placeholderDiagnosticID,
+		)
+	}
+
+	if _, ok := extractedValues.idMessages[extractedValues.placeholderDiagnosticID]; ok && extractedValues.placeholders {
+		return wraperror.Errorf(
+			errForPackage,
+			"message %d is reserved for the placeholder diagnostic; set OptionPlaceholderDiagnosticID",
+			extractedValues.placeholderDiagnosticID,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idMessagesPlaceholdersTest = map[int]string{ //nolint
+	2001: "INFO: %s works with %s",
+	2002: "The favorite number for {entityID} is {number}.",
+	2003: "{2} comes after {1}.",
+	2004: "Record {record.id} of {dataSource}.",
+}
+
+type placeholderEntityTest struct {
+	EntityID int
+	Number   int `json:"number"`
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newPlaceholdersLogger(test *testing.T, outputString *bytes.Buffer, options ...interface{}) logging.Logging {
+	test.Helper()
+
+	options = append([]interface{}{
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text"}},
+		logging.OptionPlaceholders{Value: true},
+	}, options...)
+	logger, err := logging.NewSenzingLogger(componentID, idMessagesPlaceholdersTest, options...)
+	require.NoError(test, err)
+
+	return logger
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_Log_placeholders(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger := newPlaceholdersLogger(test, outputString)
+
+	logger.Log(2001, "Bob", "Jane")
+	logger.Log(2002, map[string]string{"entityID": "Bob"}, map[string]interface{}{"number": 7})
+	logger.Log(2002, placeholderEntityTest{EntityID: 42, Number: 7})
+	logger.Log(2003, "Bob", "Jane")
+	logger.(logging.AttrLogging).With("dataSource", "CUSTOMERS").WithGroup("record").With("id", 1001).Log(2004)
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"INFO: Bob works with Jane","id":"SZTL99972001"}`+"\n"+
+			`{"level":"INFO","text":"The favorite number for Bob is 7.","id":"SZTL99972002"}`+"\n"+
+			`{"level":"INFO","text":"The favorite number for 42 is 7.","id":"SZTL99972002"}`+"\n"+
+			`{"level":"INFO","text":"Jane comes after Bob.","id":"SZTL99972003"}`+"\n"+
+			`{"level":"INFO","text":"Record 1001 of CUSTOMERS.","dataSource":"CUSTOMERS",`+
+			`"record":{"id":1001},"id":"SZTL99972004"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_Log_placeholdersUnresolved(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger := newPlaceholdersLogger(test, outputString)
+
+	logger.Log(2002, map[string]string{"entityID": "Bob"})
+	assert.Equal(
+		test,
+		`{"level":"WARN","text":"cannot resolve placeholder {number} of message 2002","id":"SZTL99973999",`+
+			`"messageNumber":2002,"placeholder":"number"}`+"\n"+
+			`{"level":"INFO","text":"The favorite number for Bob is %!{number}(MISSING).","id":"SZTL99972002"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_Log_placeholdersDiagnosticID(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger := newPlaceholdersLogger(
+		test,
+		outputString,
+		logging.OptionPlaceholderDiagnosticID{Value: 4999},
+		logging.OptionIDFilter{Value: logging.IDFilter{FirstMessageNumber: 4999, LastMessageNumber: 4999}},
+	)
+
+	logger.Log(2003, "Bob")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"%!{2}(MISSING) comes after Bob.","id":"SZTL99972003"}`+"\n",
+		outputString.String(),
+	)
+
+	_, err := logging.New(logging.OptionPlaceholderDiagnosticID{Value: -1})
+	require.Error(test, err)
+
+	idMessages := map[int]string{logging.PlaceholderDiagnosticID: "Defined by the caller"}
+	_, err = logging.NewSenzingLogger(componentID, idMessages, logging.OptionPlaceholders{Value: true})
+	require.ErrorContains(test, err, "OptionPlaceholderDiagnosticID")
+
+	_, err = logging.NewSenzingLogger(componentID, idMessages)
+	require.NoError(test, err)
+}
+
+func TestLogging_Log_placeholdersDisabled(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger := newPlaceholdersLogger(test, outputString, logging.OptionPlaceholders{Value: false})
+
+	logger.Log(2003, "Bob", "Jane")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"{2} comes after {1}.","id":"SZTL99972003"}`+"\n",
+		outputString.String(),
+	)
+}
+
+func TestLogging_NewError_placeholders(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger := newPlaceholdersLogger(test, outputString)
+
+	err := logger.NewError(2002, &placeholderEntityTest{EntityID: 42, Number: 7})
+	assert.Equal(test, `{"id":"SZTL99972002","text":"The favorite number for 42 is 7."}`, err.Error())
+	assert.JSONEq(
+		test,
+		`{"id":"SZTL99972003","text":"Overridden"}`,
+		logger.JSON(2003, "Bob", "Jane", logging.MessageText{Value: "Overridden"}),
+	)
+	assert.Empty(test, outputString.String())
+}
@@ -0,0 +1,303 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+A Record is a message written by Log(), or returned by JSON() or NewError(), parsed by ParseRecord().
+Fields missing from the message have zero values.
+
+Fields
+  - Attrs: Fields other than the message fields, e.g. attributes added by With().
+  - Code: The "code" field.
+  - Details: The "details" field.
+  - Duration: The "duration" field.
+  - Errors: The "errors" field.
+  - ID: The "id" field.
+  - Level: The "level" field.
+  - Location: The "location" field.
+  - Reason: The "reason" field.
+  - Status: The "status" field.
+  - Text: The "text" field, or the "msg" field of records written without a "text" field.
+  - Time: The "time" field.
+*/
+type Record struct {
+	Attrs    map[string]interface{}
+	Code     string
+	Details  []RecordDetail
+	Duration time.Duration
+	Errors   []RecordError
+	ID       string
+	Level    string
+	Location string
+	Reason   string
+	Status   string
+	Text     string
+	Time     time.Time
+}
+
+// A RecordDetail is one of the details of a Record.
+type RecordDetail struct {
+	Key      string      `json:"key,omitempty"`
+	Position int32       `json:"position,omitempty"`
+	Type     string      `json:"type,omitempty"`
+	Value    string      `json:"value,omitempty"`
+	ValueRaw interface{} `json:"valueRaw,omitempty"`
+}
+
+/*
+A RecordError is one of the errors of a Record.
+
+Fields
+  - Record: If the error is a message, e.g. from an earlier NewError(), the parsed message.  Otherwise nil.
+  - Text: The text of the error.
+*/
+type RecordError struct {
+	Record *Record
+	Text   string
+}
+
+/*
+A RecordScanner reads Records from newline-delimited JSON, e.g. a log file.
+Blank lines are skipped.
+*/
+type RecordScanner struct {
+	err     error
+	line    int
+	record  *Record
+	scanner *bufio.Scanner
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The longest line a RecordScanner reads.
+const maxRecordSize = 16 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Err method returns the error that stopped Scan().
+
+Output
+  - The first error reading or parsing a line.  Nil at the end of the input.
+*/
+func (recordScanner *RecordScanner) Err() error {
+	return recordScanner.err
+}
+
+/*
+The Record method returns the Record read by the last call to Scan().
+
+Output
+  - The Record.
+*/
+func (recordScanner *RecordScanner) Record() *Record {
+	return recordScanner.record
+}
+
+/*
+The Scan method reads the next Record.
+
+Output
+  - True, if a Record was read.  False at the end of the input or after an error.
+*/
+func (recordScanner *RecordScanner) Scan() bool {
+	recordScanner.record = nil
+
+	if recordScanner.err != nil {
+		return false
+	}
+
+	for recordScanner.scanner.Scan() {
+		recordScanner.line++
+
+		line := bytes.TrimSpace(recordScanner.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		record, err := ParseRecord(line)
+		if err != nil {
+			recordScanner.err = wraperror.Errorf(err, "line %d", recordScanner.line)
+
+			return false
+		}
+
+		recordScanner.record = record
+
+		return true
+	}
+
+	err := recordScanner.scanner.Err()
+	if err != nil {
+		recordScanner.err = wraperror.Errorf(err, "line %d", recordScanner.line+1)
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewRecordScanner function returns a RecordScanner reading newline-delimited JSON.
+
+Input
+  - reader: The source of the JSON, e.g. an *os.File.
+
+Output
+  - A RecordScanner.
+*/
+func NewRecordScanner(reader io.Reader) *RecordScanner {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxRecordSize)
+
+	return &RecordScanner{scanner: scanner}
+}
+
+/*
+The ParseRecord function parses a message, e.g. a line written by Log(), or the text of an error from NewError().
+Fields having unexpected types are added to Record.Attrs.
+
+Input
+  - data: A JSON object.
+
+Output
+  - The Record.
+  - error
+*/
+func ParseRecord(data []byte) (*Record, error) {
+	var fields map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "ParseRecord")
+	}
+
+	result := &Record{}
+
+	for key, raw := range fields {
+		if !result.parseField(key, raw) {
+			result.addAttr(key, raw)
+		}
+	}
+
+	if result.Text == "" {
+		if raw, ok := fields[slog.MessageKey]; ok {
+			result.Text = rawString(raw)
+			delete(result.Attrs, slog.MessageKey)
+		}
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (record *Record) addAttr(key string, raw json.RawMessage) {
+	var value interface{}
+	if json.Unmarshal(raw, &value) != nil {
+		return
+	}
+
+	if record.Attrs == nil {
+		record.Attrs = map[string]interface{}{}
+	}
+
+	record.Attrs[key] = value
+}
+
+// Set a message field.  False if the key is not a message field or the value has an unexpected type.
+func (record *Record) parseField(key string, raw json.RawMessage) bool {
+	var err error
+
+	switch key {
+	case "code":
+		record.Code = rawString(raw)
+	case "details":
+		err = json.Unmarshal(raw, &record.Details)
+	case "duration":
+		var nanoseconds int64
+
+		err = json.Unmarshal(raw, &nanoseconds)
+		record.Duration = time.Duration(nanoseconds)
+	case "errors":
+		record.Errors = parseRecordErrors(raw)
+	case "id":
+		record.ID = rawString(raw)
+	case "level":
+		record.Level = rawString(raw)
+	case "location":
+		record.Location = rawString(raw)
+	case "reason":
+		record.Reason = rawString(raw)
+	case "status":
+		record.Status = rawString(raw)
+	case "text":
+		record.Text = rawString(raw)
+	case "time":
+		record.Time, err = time.Parse(time.RFC3339Nano, rawString(raw))
+	default:
+		return false
+	}
+
+	return err == nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The errors of a message: a list of strings, or of objects for messages nested without quoting.
+func parseRecordErrors(raw json.RawMessage) []RecordError {
+	var items []json.RawMessage
+
+	err := json.Unmarshal(raw, &items)
+	if err != nil {
+		items = []json.RawMessage{raw}
+	}
+
+	result := make([]RecordError, 0, len(items))
+
+	for _, item := range items {
+		recordError := RecordError{Text: rawString(item)}
+
+		nested := bytes.TrimSpace([]byte(recordError.Text))
+		if len(nested) > 0 && nested[0] == '{' {
+			recordError.Record, _ = ParseRecord(nested)
+		}
+
+		result = append(result, recordError)
+	}
+
+	return result
+}
+
+// A JSON string without quotes, or the JSON text of other values, e.g. numbers.
+func rawString(raw json.RawMessage) string {
+	var result string
+	if json.Unmarshal(raw, &result) == nil {
+		return result
+	}
+
+	return string(bytes.TrimSpace(raw))
+}
@@ -0,0 +1,125 @@
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParseRecord(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesTest,
+		getOptionIDStatuses(),
+		logging.OptionMessageFields{Value: append([]string{"level"}, logging.AllMessageFields...)},
+	)
+	require.NoError(test, err)
+
+	record, err := logging.ParseRecord([]byte(logger.JSON(4000, "Bob", 7, logging.MessageReason{Value: "A reason"})))
+	require.NoError(test, err)
+	assert.WithinDuration(test, time.Now(), record.Time, time.Minute)
+	assert.Equal(test, logging.LevelErrorName, record.Level)
+	assert.Equal(test, "SZTL99974000", record.ID)
+	assert.Equal(test, "FAILURE", record.Status)
+	assert.Equal(test, "A reason", record.Reason)
+	require.Len(test, record.Details, 2)
+	assert.Equal(test, logging.RecordDetail{Position: 1, Type: "string", Value: "Bob"}, record.Details[0])
+	assert.Equal(test, "integer", record.Details[1].Type)
+	assert.InDelta(test, 7, record.Details[1].ValueRaw, 0)
+	assert.Empty(test, record.Errors)
+}
+
+func TestParseRecord_nestedErrors(test *testing.T) {
+	test.Parallel()
+
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesTest,
+		logging.OptionMessageFields{Value: []string{"id", "text", "errors"}},
+	)
+	require.NoError(test, err)
+
+	cause := logger.NewError(3001, "Bob", "Jane")
+	err = logger.NewError(4001, "Mary", "Jane", cause, errTest)
+
+	record, err := logging.ParseRecord([]byte(err.Error()))
+	require.NoError(test, err)
+	assert.Equal(test, "SZTL99974001", record.ID)
+	assert.Equal(test, "ERROR: Mary works with Jane", record.Text)
+	require.Len(test, record.Errors, 2)
+	require.NotNil(test, record.Errors[0].Record)
+	assert.Equal(test, "SZTL99973001", record.Errors[0].Record.ID)
+	assert.Equal(test, "WARN: Bob works with Jane", record.Errors[0].Record.Text)
+	assert.Equal(test, errTest.Error(), record.Errors[1].Text)
+	assert.Nil(test, record.Errors[1].Record)
+}
+
+func TestParseRecord_tolerant(test *testing.T) {
+	test.Parallel()
+
+	record, err := logging.ParseRecord([]byte(`{"id":2001,"msg":"Hello","duration":"soon","jobID":"job-20"}`))
+	require.NoError(test, err)
+	assert.Equal(test, "2001", record.ID)
+	assert.Equal(test, "Hello", record.Text)
+	assert.Zero(test, record.Duration)
+	assert.True(test, record.Time.IsZero())
+	assert.Equal(test, map[string]interface{}{"duration": "soon", "jobID": "job-20"}, record.Attrs)
+
+	_, err = logging.ParseRecord([]byte(`not JSON`))
+	require.Error(test, err)
+}
+
+func TestRecordScanner(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text", "duration"}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane")
+	outputString.WriteString("\n")
+	logger.(logging.AttrLogging).With("jobID", "job-20").Log(3001, "Bob", "Mary", 2*time.Second)
+
+	recordScanner := logging.NewRecordScanner(outputString)
+	records := []*logging.Record{}
+
+	for recordScanner.Scan() {
+		records = append(records, recordScanner.Record())
+	}
+
+	require.NoError(test, recordScanner.Err())
+	require.Len(test, records, 2)
+	assert.Equal(test, logging.LevelInfoName, records[0].Level)
+	assert.Equal(test, "INFO: Bob works with Jane", records[0].Text)
+	assert.Equal(test, logging.LevelWarnName, records[1].Level)
+	assert.Equal(test, "3001", records[1].ID)
+	assert.Equal(test, 2*time.Second, records[1].Duration)
+	assert.Equal(test, "job-20", records[1].Attrs["jobID"])
+	assert.False(test, records[1].Time.IsZero())
+}
+
+func TestRecordScanner_bad(test *testing.T) {
+	test.Parallel()
+
+	recordScanner := logging.NewRecordScanner(strings.NewReader(`{"id":"1"}` + "\npanic: oops\n" + `{"id":"2"}`))
+	require.True(test, recordScanner.Scan())
+	assert.Equal(test, "1", recordScanner.Record().ID)
+	require.False(test, recordScanner.Scan())
+	require.ErrorContains(test, recordScanner.Err(), "line 2")
+	require.False(test, recordScanner.Scan())
+}
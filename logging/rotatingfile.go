@@ -0,0 +1,567 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+The RotatingFile type is an io.WriteCloser that writes to a file and rotates it.
+A rotated file is renamed to a backup file with the time of rotation in its name,
+e.g. "my-program-2026-01-02T03-04-05.000.log", and a new file is created.
+Old backups are removed and compressed in the background; errors doing so are returned by Flush() and Close().
+It is safe for concurrent use.
+*/
+type RotatingFile struct {
+	cleanupDone      chan struct{}
+	cleanupErr       error
+	closed           bool
+	compress         bool
+	file             *os.File
+	filename         string
+	maxAge           time.Duration
+	maxBackups       int
+	maxSize          int64
+	mutex            sync.Mutex
+	nextRotation     time.Time
+	rotationInterval time.Duration
+	signals          chan os.Signal
+	size             int64
+}
+
+// --- Options for NewRotatingFile() ------------------------------------------
+
+// Gzip-compress backup files.
+type OptionFileCompress struct {
+	Value bool
+}
+
+// Delete backup files older than the duration.
+type OptionFileMaxAge struct {
+	Value time.Duration
+}
+
+// The number of backup files to keep.
+type OptionFileMaxBackups struct {
+	Value int
+}
+
+// Rotate the file before it grows beyond the number of bytes.
+type OptionFileMaxSize struct {
+	Value int64
+}
+
+// Reopen the file on SIGHUP, for use with external tools like logrotate.  Not available on Windows.
+type OptionFileReopenOnSignal struct {
+	Value bool
+}
+
+// Rotate the file at multiples of the interval, e.g. 24 * time.Hour for daily files.
+type OptionFileRotationInterval struct {
+	Value time.Duration
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+	filePermissions  = 0o644
+	dirPermissions   = 0o755
+)
+
+// ----------------------------------------------------------------------------
+// io.WriteCloser interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Close method closes the file, stops listening for signals, and waits for the background cleanup of backups.
+Later calls to Write(), Reopen(), and Rotate() return an error.
+*/
+func (rotatingFile *RotatingFile) Close() error {
+	rotatingFile.mutex.Lock()
+
+	if rotatingFile.signals != nil {
+		signal.Stop(rotatingFile.signals)
+		close(rotatingFile.signals)
+		rotatingFile.signals = nil
+	}
+
+	rotatingFile.closed = true
+	err := rotatingFile.closeFile()
+	rotatingFile.mutex.Unlock()
+
+	return errors.Join(err, rotatingFile.waitForCleanup())
+}
+
+/*
+The Write method writes to the file, rotating it first if needed.
+An error removing or compressing old backups does not fail the write; Flush() and Close() return it.
+*/
+func (rotatingFile *RotatingFile) Write(data []byte) (int, error) {
+	rotatingFile.mutex.Lock()
+	defer rotatingFile.mutex.Unlock()
+
+	if rotatingFile.closed {
+		return 0, rotatingFile.closedError()
+	}
+
+	var err error
+
+	if rotatingFile.file == nil {
+		err = rotatingFile.openFile()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if rotatingFile.needsRotation(len(data)) {
+		err = rotatingFile.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	written, err := rotatingFile.file.Write(data)
+	rotatingFile.size += int64(written)
+
+	if err != nil {
+		return written, wraperror.Errorf(err, "Write")
+	}
+
+	return written, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Flush method commits the file to storage and waits for the background cleanup of backups.
+
+Output
+  - error: Also an error removing or compressing old backups since the last Flush(), Rotate(), or Close().
+*/
+func (rotatingFile *RotatingFile) Flush() error {
+	var err error
+
+	rotatingFile.mutex.Lock()
+
+	if rotatingFile.file != nil {
+		err = rotatingFile.file.Sync()
+		if err != nil {
+			err = wraperror.Errorf(err, "Sync")
+		}
+	}
+
+	rotatingFile.mutex.Unlock()
+
+	return errors.Join(err, rotatingFile.waitForCleanup())
+}
+
+/*
+The Reopen method closes and reopens the file without rotating it.
+Use it after an external tool has renamed the file.
+*/
+func (rotatingFile *RotatingFile) Reopen() error {
+	rotatingFile.mutex.Lock()
+	defer rotatingFile.mutex.Unlock()
+
+	if rotatingFile.closed {
+		return rotatingFile.closedError()
+	}
+
+	err := rotatingFile.closeFile()
+	if err != nil {
+		return err
+	}
+
+	return rotatingFile.openFile()
+}
+
+/*
+The Rotate method renames the file to a backup file and creates a new file.
+It waits for old backups to be removed and compressed.
+*/
+func (rotatingFile *RotatingFile) Rotate() error {
+	rotatingFile.mutex.Lock()
+
+	if rotatingFile.closed {
+		rotatingFile.mutex.Unlock()
+
+		return rotatingFile.closedError()
+	}
+
+	err := rotatingFile.rotate()
+	rotatingFile.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return rotatingFile.waitForCleanup()
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewRotatingFile function creates a RotatingFile for use with OptionOutput or Sink.Output.
+The file is created if it does not exist and appended to if it does.
+
+Input
+  - filename: The path of the file.
+  - options: OptionFileXxxxx values.  By default, the file is never rotated.
+
+Output
+  - A RotatingFile
+  - error
+*/
+func NewRotatingFile(filename string, options ...interface{}) (*RotatingFile, error) {
+	result := &RotatingFile{
+		filename: filename,
+	}
+
+	reopenOnSignal := false
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionFileCompress:
+			result.compress = typedValue.Value
+		case OptionFileMaxAge:
+			result.maxAge = typedValue.Value
+		case OptionFileMaxBackups:
+			result.maxBackups = typedValue.Value
+		case OptionFileMaxSize:
+			result.maxSize = typedValue.Value
+		case OptionFileReopenOnSignal:
+			reopenOnSignal = typedValue.Value
+		case OptionFileRotationInterval:
+			result.rotationInterval = typedValue.Value
+		}
+	}
+
+	if result.maxSize < 0 || result.maxBackups < 0 || result.maxAge < 0 || result.rotationInterval < 0 {
+		return nil, wraperror.Errorf(errForPackage, "negative rotation option for file: %s", filename)
+	}
+
+	err := result.openFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if reopenOnSignal && len(reopenSignals) > 0 {
+		result.signals = make(chan os.Signal, 1)
+		signal.Notify(result.signals, reopenSignals...)
+
+		go result.reopenOnSignal(result.signals)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The backup files, newest first.
+func (rotatingFile *RotatingFile) backups() ([]string, error) {
+	dir := filepath.Dir(rotatingFile.filename)
+	prefix, extension := rotatingFile.prefixAndExtension()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "ReadDir")
+	}
+
+	result := []string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		if !strings.HasSuffix(name, extension) && !strings.HasSuffix(name, extension+compressSuffix) {
+			continue
+		}
+
+		_, err := rotatingFile.backupTime(name)
+		if err == nil {
+			result = append(result, name)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(result)))
+
+	return result, nil
+}
+
+func (rotatingFile *RotatingFile) backupName(rotationTime time.Time) string {
+	prefix, extension := rotatingFile.prefixAndExtension()
+
+	return filepath.Join(
+		filepath.Dir(rotatingFile.filename),
+		prefix+rotationTime.UTC().Format(backupTimeFormat)+extension,
+	)
+}
+
+func (rotatingFile *RotatingFile) backupTime(name string) (time.Time, error) {
+	prefix, extension := rotatingFile.prefixAndExtension()
+	timestamp := strings.TrimSuffix(strings.TrimSuffix(name, compressSuffix), extension)
+	timestamp = strings.TrimPrefix(timestamp, prefix)
+
+	result, err := time.Parse(backupTimeFormat, timestamp)
+	if err != nil {
+		return result, wraperror.Errorf(err, "Parse")
+	}
+
+	return result, nil
+}
+
+// Remove old backups and compress the others.
+func (rotatingFile *RotatingFile) cleanup() error {
+	backups, err := rotatingFile.backups()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(rotatingFile.filename)
+	cutoff := time.Now().Add(-rotatingFile.maxAge)
+
+	for index, name := range backups {
+		path := filepath.Join(dir, name)
+		backupTime, _ := rotatingFile.backupTime(name)
+		tooMany := rotatingFile.maxBackups > 0 && index >= rotatingFile.maxBackups
+		tooOld := rotatingFile.maxAge > 0 && backupTime.Before(cutoff)
+
+		switch {
+		case tooMany || tooOld:
+			err = os.Remove(path)
+		case rotatingFile.compress && !strings.HasSuffix(name, compressSuffix):
+			err = compressFile(path)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run cleanup() in the background, after the cleanup of earlier rotations.  Errors are kept for waitForCleanup().
+func (rotatingFile *RotatingFile) cleanupInBackground() {
+	previous := rotatingFile.cleanupDone
+	done := make(chan struct{})
+	rotatingFile.cleanupDone = done
+
+	go func() {
+		defer close(done)
+
+		if previous != nil {
+			<-previous
+		}
+
+		err := rotatingFile.cleanup()
+		if err != nil {
+			rotatingFile.mutex.Lock()
+			rotatingFile.cleanupErr = errors.Join(rotatingFile.cleanupErr, err)
+			rotatingFile.mutex.Unlock()
+		}
+	}()
+}
+
+func (rotatingFile *RotatingFile) closeFile() error {
+	if rotatingFile.file == nil {
+		return nil
+	}
+
+	err := rotatingFile.file.Close()
+	rotatingFile.file = nil
+
+	if err != nil {
+		return wraperror.Errorf(err, "Close")
+	}
+
+	return nil
+}
+
+func (rotatingFile *RotatingFile) closedError() error {
+	return wraperror.Errorf(errForPackage, "file is closed: %s", rotatingFile.filename)
+}
+
+func (rotatingFile *RotatingFile) needsRotation(length int) bool {
+	if rotatingFile.maxSize > 0 && rotatingFile.size > 0 && rotatingFile.size+int64(length) > rotatingFile.maxSize {
+		return true
+	}
+
+	return rotatingFile.rotationInterval > 0 && !time.Now().Before(rotatingFile.nextRotation)
+}
+
+func (rotatingFile *RotatingFile) openFile() error {
+	err := os.MkdirAll(filepath.Dir(rotatingFile.filename), dirPermissions)
+	if err != nil {
+		return wraperror.Errorf(err, "MkdirAll")
+	}
+
+	file, err := os.OpenFile(rotatingFile.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+	if err != nil {
+		return wraperror.Errorf(err, "OpenFile")
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return wraperror.Errorf(err, "Stat")
+	}
+
+	rotatingFile.file = file
+	rotatingFile.size = fileInfo.Size()
+
+	if rotatingFile.rotationInterval > 0 {
+		rotatingFile.nextRotation = time.Now().Truncate(rotatingFile.rotationInterval).Add(rotatingFile.rotationInterval)
+	}
+
+	return nil
+}
+
+// For "/var/log/my-program.log", returns "my-program-" and ".log".
+func (rotatingFile *RotatingFile) prefixAndExtension() (string, string) {
+	base := filepath.Base(rotatingFile.filename)
+	extension := filepath.Ext(base)
+
+	return strings.TrimSuffix(base, extension) + "-", extension
+}
+
+func (rotatingFile *RotatingFile) reopenOnSignal(signals chan os.Signal) {
+	for range signals {
+		_ = rotatingFile.Reopen()
+	}
+}
+
+func (rotatingFile *RotatingFile) rotate() error {
+	err := rotatingFile.closeFile()
+	if err != nil {
+		return err
+	}
+
+	// Avoid overwriting a backup made in the same millisecond.
+
+	rotationTime := time.Now()
+	for {
+		_, err = os.Stat(rotatingFile.backupName(rotationTime))
+		if os.IsNotExist(err) {
+			break
+		}
+
+		rotationTime = rotationTime.Add(time.Millisecond)
+	}
+
+	err = os.Rename(rotatingFile.filename, rotatingFile.backupName(rotationTime))
+	if err != nil && !os.IsNotExist(err) {
+		return wraperror.Errorf(err, "Rename")
+	}
+
+	err = rotatingFile.openFile()
+	if err != nil {
+		return err
+	}
+
+	if rotatingFile.maxBackups > 0 || rotatingFile.maxAge > 0 || rotatingFile.compress {
+		rotatingFile.cleanupInBackground()
+	}
+
+	return nil
+}
+
+// Wait for the background cleanup.  Returns, and forgets, the errors of cleanups since the last call.
+func (rotatingFile *RotatingFile) waitForCleanup() error {
+	rotatingFile.mutex.Lock()
+	done := rotatingFile.cleanupDone
+	rotatingFile.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+
+	rotatingFile.mutex.Lock()
+	defer rotatingFile.mutex.Unlock()
+
+	err := rotatingFile.cleanupErr
+	rotatingFile.cleanupErr = nil
+
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Replace a file with a gzip-compressed copy having a ".gz" suffix.
+func compressFile(path string) error {
+	err := writeCompressed(path, path+compressSuffix)
+	if err != nil {
+		_ = os.Remove(path + compressSuffix)
+
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return wraperror.Errorf(err, "Remove")
+	}
+
+	return nil
+}
+
+func writeCompressed(sourcePath string, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return wraperror.Errorf(err, "Open")
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return wraperror.Errorf(err, "OpenFile")
+	}
+	defer target.Close()
+
+	gzipWriter := gzip.NewWriter(target)
+
+	_, err = io.Copy(gzipWriter, source)
+	if err != nil {
+		return wraperror.Errorf(err, "Copy")
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "Close")
+	}
+
+	err = target.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "Close")
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package logging_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRotatingFile_maxSize(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	rotatingFile, err := logging.NewRotatingFile(
+		filename,
+		logging.OptionFileMaxSize{Value: 10},
+		logging.OptionFileMaxBackups{Value: 2},
+	)
+	require.NoError(test, err)
+
+	defer rotatingFile.Close()
+
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		_, err = rotatingFile.Write([]byte(line))
+		require.NoError(test, err)
+	}
+
+	assert.Equal(test, "line-4\n", readFile(test, filename))
+	require.NoError(test, rotatingFile.Flush())
+
+	backups := listBackups(test, dir, "test-")
+	require.Len(test, backups, 2)
+	assert.Equal(test, "line-2\n", readFile(test, filepath.Join(dir, backups[0])))
+	assert.Equal(test, "line-3\n", readFile(test, filepath.Join(dir, backups[1])))
+}
+
+func TestRotatingFile_cleanupError(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	oldBackup := filepath.Join(dir, "test-2020-01-02T03-04-05.000.log")
+	err := os.WriteFile(oldBackup, []byte("old\n"), 0o600)
+	require.NoError(test, err)
+
+	// The compressed backup cannot be written over a directory.
+
+	err = os.Mkdir(oldBackup+".gz", 0o755)
+	require.NoError(test, err)
+
+	rotatingFile, err := logging.NewRotatingFile(
+		filename,
+		logging.OptionFileMaxSize{Value: 10},
+		logging.OptionFileCompress{Value: true},
+	)
+	require.NoError(test, err)
+
+	for _, line := range []string{"line-1\n", "line-2\n"} {
+		_, err = rotatingFile.Write([]byte(line))
+		require.NoError(test, err)
+	}
+
+	require.Error(test, rotatingFile.Flush())
+	require.NoError(test, rotatingFile.Close())
+	assert.Equal(test, "line-2\n", readFile(test, filename))
+}
+
+func TestRotatingFile_Close(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "test.log")
+	rotatingFile, err := logging.NewRotatingFile(filename)
+	require.NoError(test, err)
+
+	_, err = rotatingFile.Write([]byte("before\n"))
+	require.NoError(test, err)
+	require.NoError(test, rotatingFile.Close())
+
+	_, err = rotatingFile.Write([]byte("after\n"))
+	require.Error(test, err)
+	require.Error(test, rotatingFile.Reopen())
+	require.Error(test, rotatingFile.Rotate())
+	assert.Equal(test, "before\n", readFile(test, filename))
+}
+
+func TestRotatingFile_compress(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	rotatingFile, err := logging.NewRotatingFile(filename, logging.OptionFileCompress{Value: true})
+	require.NoError(test, err)
+
+	defer rotatingFile.Close()
+
+	_, err = rotatingFile.Write([]byte("compressed\n"))
+	require.NoError(test, err)
+	err = rotatingFile.Rotate()
+	require.NoError(test, err)
+
+	backups := listBackups(test, dir, "test-")
+	require.Len(test, backups, 1)
+	assert.True(test, strings.HasSuffix(backups[0], ".log.gz"))
+
+	file, err := os.Open(filepath.Join(dir, backups[0]))
+	require.NoError(test, err)
+
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	require.NoError(test, err)
+	content, err := io.ReadAll(gzipReader)
+	require.NoError(test, err)
+	assert.Equal(test, "compressed\n", string(content))
+}
+
+func TestRotatingFile_maxAge(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	oldBackup := filepath.Join(dir, "test-2020-01-02T03-04-05.000.log")
+	err := os.WriteFile(oldBackup, []byte("old\n"), 0o600)
+	require.NoError(test, err)
+
+	rotatingFile, err := logging.NewRotatingFile(filename, logging.OptionFileMaxAge{Value: 24 * time.Hour})
+	require.NoError(test, err)
+
+	defer rotatingFile.Close()
+
+	err = rotatingFile.Rotate()
+	require.NoError(test, err)
+	assert.NoFileExists(test, oldBackup)
+	assert.Len(test, listBackups(test, dir, "test-"), 1)
+}
+
+func TestRotatingFile_rotationInterval(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	rotatingFile, err := logging.NewRotatingFile(filename, logging.OptionFileRotationInterval{Value: 20 * time.Millisecond})
+	require.NoError(test, err)
+
+	defer rotatingFile.Close()
+
+	_, err = rotatingFile.Write([]byte("before\n"))
+	require.NoError(test, err)
+	time.Sleep(40 * time.Millisecond)
+	_, err = rotatingFile.Write([]byte("after\n"))
+	require.NoError(test, err)
+
+	assert.Equal(test, "after\n", readFile(test, filename))
+	assert.Len(test, listBackups(test, dir, "test-"), 1)
+}
+
+func TestRotatingFile_Reopen(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	rotatingFile, err := logging.NewRotatingFile(filename, logging.OptionFileReopenOnSignal{Value: true})
+	require.NoError(test, err)
+
+	defer rotatingFile.Close()
+
+	_, err = rotatingFile.Write([]byte("first\n"))
+	require.NoError(test, err)
+
+	// Simulate logrotate moving the file.
+
+	err = os.Rename(filename, filename+".1")
+	require.NoError(test, err)
+	err = rotatingFile.Reopen()
+	require.NoError(test, err)
+	_, err = rotatingFile.Write([]byte("second\n"))
+	require.NoError(test, err)
+
+	assert.Equal(test, "first\n", readFile(test, filename+".1"))
+	assert.Equal(test, "second\n", readFile(test, filename))
+}
+
+func TestRotatingFile_badOption(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.NewRotatingFile(filepath.Join(test.TempDir(), "test.log"), logging.OptionFileMaxSize{Value: -1})
+	require.Error(test, err)
+}
+
+func TestRotatingFile_concurrent(test *testing.T) {
+	test.Parallel()
+
+	dir := test.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	rotatingFile, err := logging.NewRotatingFile(filename, logging.OptionFileMaxSize{Value: 1000})
+	require.NoError(test, err)
+	logger, err := logging.New(logging.OptionOutput{Value: rotatingFile}, getOptionTimeHidden())
+	require.NoError(test, err)
+
+	var waitGroup sync.WaitGroup
+
+	for range 10 {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for range 50 {
+				logger.Log(2001, "Bob", "Jane")
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	err = rotatingFile.Close()
+	require.NoError(test, err)
+
+	lines := strings.Count(readFile(test, filename), "\n")
+	for _, backup := range listBackups(test, dir, "test-") {
+		lines += strings.Count(readFile(test, filepath.Join(dir, backup)), "\n")
+	}
+
+	assert.Equal(test, 500, lines)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Backup files, oldest first.
+func listBackups(test *testing.T, dir string, prefix string) []string {
+	test.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(test, err)
+
+	result := []string{}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) {
+			result = append(result, entry.Name())
+		}
+	}
+
+	return result
+}
+
+func readFile(test *testing.T, filename string) string {
+	test.Helper()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(test, err)
+
+	return string(content)
+}
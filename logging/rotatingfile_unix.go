@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logging
+
+import (
+	"os"
+	"syscall"
+)
+
+// Signals which cause a RotatingFile to reopen its file.
+var reopenSignals = []os.Signal{syscall.SIGHUP} //nolint
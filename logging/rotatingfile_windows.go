@@ -0,0 +1,8 @@
+//go:build windows
+
+package logging
+
+import "os"
+
+// Windows has no SIGHUP, so a RotatingFile does not reopen on a signal.
+var reopenSignals = []os.Signal{} //nolint
@@ -0,0 +1,276 @@
+package logging
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The sampler type decides which messages are logged when OptionSampling is used.
+type sampler struct {
+	closed    bool
+	mutex     sync.Mutex
+	samplings []Sampling
+	states    map[int]*samplingState
+	summarize func(messageNumber int, suppressed int, interval time.Duration)
+	sweepAt   time.Time
+	sweeper   *time.Timer
+}
+
+// The samplingState type counts the messages with one message number in the current interval.
+type samplingState struct {
+	count      int
+	interval   time.Duration
+	start      time.Time
+	summaryAt  time.Time
+	suppressed int
+}
+
+// The samplingSummary type is the summary of the messages with one message number suppressed in an interval.
+type samplingSummary struct {
+	interval      time.Duration
+	messageNumber int
+	suppressed    int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const samplingInterval = time.Second
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Returns true if the message should be logged.  A nil sampler logs every message.
+// When a new interval starts, the summary of the previous interval is logged first, if it has not been yet.
+func (sampler *sampler) allow(messageNumber int) bool {
+	if sampler == nil {
+		return true
+	}
+
+	sampling, ok := sampler.find(messageNumber)
+	if !ok {
+		return true
+	}
+
+	var (
+		now       = time.Now()
+		summaries []samplingSummary
+	)
+
+	sampler.mutex.Lock()
+
+	state, ok := sampler.states[messageNumber]
+	if !ok || now.Sub(state.start) >= sampling.Interval {
+		if !ok {
+			state = &samplingState{}
+			sampler.states[messageNumber] = state
+		}
+
+		summaries = state.take(messageNumber)
+		state.count = 0
+		state.start = now
+	}
+
+	state.count++
+
+	result := state.count <= sampling.Initial ||
+		(sampling.Thereafter > 0 && (state.count-sampling.Initial)%sampling.Thereafter == 0)
+
+	if !result {
+		state.suppressed++
+
+		if state.summaryAt.IsZero() {
+			state.interval = sampling.Interval
+			state.summaryAt = state.start.Add(sampling.Interval)
+			sampler.schedule(state.summaryAt, now)
+		}
+	}
+
+	sampler.mutex.Unlock()
+
+	sampler.logSummaries(summaries)
+
+	return result
+}
+
+// Log summaries of suppressed messages now rather than at the end of their intervals.
+func (sampler *sampler) close() {
+	if sampler == nil {
+		return
+	}
+
+	sampler.mutex.Lock()
+
+	sampler.closed = true
+
+	if sampler.sweeper != nil {
+		sampler.sweeper.Stop()
+		sampler.sweeper = nil
+	}
+
+	summaries := sampler.due(time.Time{})
+	sampler.mutex.Unlock()
+
+	sampler.logSummaries(summaries)
+}
+
+/*
+Take the summaries due by the time, or all summaries if the time is zero.
+Returns them in message number order.  The caller holds the mutex.
+*/
+func (sampler *sampler) due(now time.Time) []samplingSummary {
+	result := []samplingSummary{}
+
+	for messageNumber, state := range sampler.states {
+		if !now.IsZero() && state.summaryAt.After(now) {
+			continue
+		}
+
+		result = append(result, state.take(messageNumber)...)
+	}
+
+	slices.SortFunc(result, func(summary1 samplingSummary, summary2 samplingSummary) int {
+		return cmp.Compare(summary1.messageNumber, summary2.messageNumber)
+	})
+
+	return result
+}
+
+// The narrowest Sampling whose range includes the message number.
+func (sampler *sampler) find(messageNumber int) (Sampling, bool) {
+	for _, sampling := range sampler.samplings {
+		if messageNumber >= sampling.FirstMessageNumber && messageNumber <= sampling.LastMessageNumber {
+			return sampling, true
+		}
+	}
+
+	return Sampling{}, false
+}
+
+func (sampler *sampler) logSummaries(summaries []samplingSummary) {
+	for _, summary := range summaries {
+		sampler.summarize(summary.messageNumber, summary.suppressed, summary.interval)
+	}
+}
+
+/*
+Make sure the sweeper runs by the time.  One timer serves all message numbers.
+If the sweeper is already running, it schedules the next sweep itself.
+After close(), no sweep is scheduled, as the outputs are closed.  The caller holds the mutex.
+*/
+func (sampler *sampler) schedule(sweepAt time.Time, now time.Time) {
+	if sampler.closed {
+		return
+	}
+
+	if sampler.sweeper != nil {
+		if !sampler.sweepAt.After(sweepAt) || !sampler.sweeper.Stop() {
+			return
+		}
+	}
+
+	sampler.sweepAt = sweepAt
+	sampler.sweeper = time.AfterFunc(sweepAt.Sub(now), sampler.sweep)
+}
+
+// Log the summaries of the intervals that have ended.
+func (sampler *sampler) sweep() {
+	now := time.Now()
+
+	sampler.mutex.Lock()
+
+	summaries := sampler.due(now)
+	sampler.sweeper = nil
+
+	for _, state := range sampler.states {
+		if !state.summaryAt.IsZero() {
+			sampler.schedule(state.summaryAt, now)
+		}
+	}
+
+	sampler.mutex.Unlock()
+
+	sampler.logSummaries(summaries)
+}
+
+// The pending summary of the interval, if messages were suppressed in it.  The caller holds the mutex.
+func (state *samplingState) take(messageNumber int) []samplingSummary {
+	if state.summaryAt.IsZero() {
+		return nil
+	}
+
+	result := []samplingSummary{{
+		interval:      state.interval,
+		messageNumber: messageNumber,
+		suppressed:    state.suppressed,
+	}}
+	state.summaryAt = time.Time{}
+	state.suppressed = 0
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// A sampler for the samplings, or nil if there are none.
+func newSampler(samplings []Sampling) *sampler {
+	if len(samplings) == 0 {
+		return nil
+	}
+
+	result := &sampler{
+		samplings: slices.Clone(samplings),
+		states:    map[int]*samplingState{},
+	}
+
+	for index := range result.samplings {
+		if result.samplings[index].Interval == 0 {
+			result.samplings[index].Interval = samplingInterval
+		}
+	}
+
+	slices.SortStableFunc(result.samplings, func(sampling1 Sampling, sampling2 Sampling) int {
+		return cmp.Compare(
+			sampling1.LastMessageNumber-sampling1.FirstMessageNumber,
+			sampling2.LastMessageNumber-sampling2.FirstMessageNumber,
+		)
+	})
+
+	return result
+}
+
+func verifySamplings(samplings []Sampling) error {
+	for _, sampling := range samplings {
+		if sampling.FirstMessageNumber > sampling.LastMessageNumber {
+			return wraperror.Errorf(
+				errForPackage,
+				"sampling message numbers %d..%d are not in order",
+				sampling.FirstMessageNumber,
+				sampling.LastMessageNumber,
+			)
+		}
+
+		if sampling.Initial < 0 || sampling.Thereafter < 0 || sampling.Interval < 0 {
+			return wraperror.Errorf(
+				errForPackage,
+				"sampling of message numbers %d..%d has a negative value",
+				sampling.FirstMessageNumber,
+				sampling.LastMessageNumber,
+			)
+		}
+	}
+
+	return nil
+}
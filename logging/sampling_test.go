@@ -0,0 +1,210 @@
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A bytes.Buffer that can be written by a timer goroutine while a test reads it.
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (writer *syncBuffer) String() string {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.String()
+}
+
+func (writer *syncBuffer) Write(data []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+
+	return writer.buffer.Write(data) //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionSampling(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionSampling{Value: logging.Sampling{
+			FirstMessageNumber: 3000,
+			Initial:            2,
+			Interval:           time.Hour,
+			LastMessageNumber:  3999,
+			Thereafter:         3,
+		}},
+	)
+	require.NoError(test, err)
+
+	for range 10 {
+		logger.Log(3001)
+	}
+
+	logger.Log(2001)
+	assert.Equal(test, 4, strings.Count(outputString.String(), `"id":"3001"`))
+	assert.Contains(test, outputString.String(), `"id":"2001"`)
+
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Contains(
+		test,
+		outputString.String(),
+		`{"level":"WARN","text":"6 messages suppressed in 1h0m0s","id":"3001","suppressed":6}`,
+	)
+}
+
+func TestLogging_New_optionSamplings_narrowestRange(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionSamplings{Value: []logging.Sampling{
+			{FirstMessageNumber: 3000, LastMessageNumber: 3999, Initial: 1, Interval: time.Hour},
+			{FirstMessageNumber: 3002, LastMessageNumber: 3002, Initial: 3, Interval: time.Hour},
+		}},
+	)
+	require.NoError(test, err)
+
+	for range 5 {
+		logger.Log(3001)
+		logger.Log(3002)
+	}
+
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"id":"3001"`))
+	assert.Equal(test, 3, strings.Count(outputString.String(), `"id":"3002"`))
+}
+
+func TestLogging_New_optionSampling_summaryAfterInterval(test *testing.T) {
+	test.Parallel()
+
+	outputString := &syncBuffer{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionOutput{Value: outputString},
+		logging.OptionSampling{Value: logging.Sampling{
+			FirstMessageNumber: 3001,
+			Initial:            1,
+			Interval:           50 * time.Millisecond,
+			LastMessageNumber:  3001,
+		}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(3001)
+	logger.Log(3001)
+	logger.Log(3001)
+	assert.Eventually(test, func() bool {
+		return strings.Contains(outputString.String(), `"suppressed":2`)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLogging_New_optionSampling_summaryPerInterval(test *testing.T) {
+	test.Parallel()
+
+	outputString := &syncBuffer{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionOutput{Value: outputString},
+		logging.OptionSampling{Value: logging.Sampling{
+			FirstMessageNumber: 3001,
+			Initial:            1,
+			Interval:           50 * time.Millisecond,
+			LastMessageNumber:  3001,
+		}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(3001)
+	logger.Log(3001)
+	logger.Log(3001)
+	time.Sleep(60 * time.Millisecond)
+	logger.Log(3001)
+	logger.Log(3001)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"suppressed":2`))
+	assert.Equal(test, 1, strings.Count(outputString.String(), `"suppressed":1`))
+}
+
+func TestLogging_New_optionSampling_afterClose(test *testing.T) {
+	test.Parallel()
+
+	outputString := &syncBuffer{}
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionOutput{Value: outputString},
+		logging.OptionSampling{Value: logging.Sampling{
+			FirstMessageNumber: 3001,
+			Initial:            1,
+			Interval:           10 * time.Millisecond,
+			LastMessageNumber:  3001,
+		}},
+	)
+	require.NoError(test, err)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+
+	logger.Log(3001)
+	logger.Log(3001)
+	time.Sleep(50 * time.Millisecond)
+	assert.NotContains(test, outputString.String(), `"suppressed"`)
+}
+
+func TestLogging_New_optionSampling_belowLogLevel(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		getOptionLogLevel(logging.LevelWarnName),
+		logging.OptionSampling{Value: logging.Sampling{
+			FirstMessageNumber: 2000,
+			Initial:            1,
+			Interval:           time.Hour,
+			LastMessageNumber:  2999,
+		}},
+	)
+	require.NoError(test, err)
+
+	// Messages dropped by the log level are not counted, so no summary is logged.
+
+	logger.Log(2001)
+	logger.Log(2001)
+	require.NoError(test, logger.SetLogLevel(logging.LevelInfoName))
+	logger.Log(2001)
+	require.NoError(test, logger.(logging.LifecycleLogging).Close())
+	assert.Equal(test, `{"level":"INFO","id":"2001"}`+"\n", outputString.String())
+}
+
+func TestLogging_New_optionSampling_bad(test *testing.T) {
+	test.Parallel()
+
+	for _, sampling := range []logging.Sampling{
+		{FirstMessageNumber: 3999, LastMessageNumber: 3000},
+		{FirstMessageNumber: 3000, LastMessageNumber: 3999, Initial: -1},
+	} {
+		_, err := logging.New(logging.OptionSampling{Value: sampling})
+		require.Error(test, err)
+	}
+}
@@ -0,0 +1,307 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sync/atomic"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The fanoutHandler type is an slog.Handler that passes each record to every sink that is enabled for its level.
+// Records enabled by an IDFilter are passed to every sink.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+// The fieldFilterHandler type is an slog.Handler that removes message fields not selected for a sink.
+type fieldFilterHandler struct {
+	handler       slog.Handler
+	messageFields []string
+}
+
+// The sinkLeveler type is the slog.Leveler of a sink.
+// Until a level is set with Sink.LogLevel or SetSinkLogLevel(), it follows the level of the logger.
+type sinkLeveler struct {
+	explicit      atomic.Bool
+	level         slog.LevelVar
+	loggerLeveler slog.Leveler
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods - fanoutHandler
+// ----------------------------------------------------------------------------
+
+func (handler *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, sinkHandler := range handler.handlers {
+		if sinkHandler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (handler *fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	for _, sinkHandler := range handler.handlers {
+		if isForced(ctx) || sinkHandler.Enabled(ctx, record.Level) {
+			errs = append(errs, sinkHandler.Handle(ctx, record.Clone()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (handler *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := &fanoutHandler{handlers: make([]slog.Handler, 0, len(handler.handlers))}
+	for _, sinkHandler := range handler.handlers {
+		result.handlers = append(result.handlers, sinkHandler.WithAttrs(attrs))
+	}
+
+	return result
+}
+
+func (handler *fanoutHandler) WithGroup(name string) slog.Handler {
+	result := &fanoutHandler{handlers: make([]slog.Handler, 0, len(handler.handlers))}
+	for _, sinkHandler := range handler.handlers {
+		result.handlers = append(result.handlers, sinkHandler.WithGroup(name))
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// slog.Handler interface methods - fieldFilterHandler
+// ----------------------------------------------------------------------------
+
+func (handler *fieldFilterHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.handler.Enabled(ctx, level)
+}
+
+func (handler *fieldFilterHandler) Handle(ctx context.Context, record slog.Record) error {
+	message := record.Message
+	if !slices.Contains(handler.messageFields, "text") {
+		message = ""
+	}
+
+	result := slog.NewRecord(record.Time, record.Level, message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		if !isMessageField(attr.Key) || slices.Contains(handler.messageFields, attr.Key) {
+			result.AddAttrs(attr)
+		}
+
+		return true
+	})
+
+	return handler.handler.Handle(ctx, result) //nolint:wrapcheck
+}
+
+func (handler *fieldFilterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &fieldFilterHandler{handler: handler.handler.WithAttrs(attrs), messageFields: handler.messageFields}
+}
+
+func (handler *fieldFilterHandler) WithGroup(name string) slog.Handler {
+	return &fieldFilterHandler{handler: handler.handler.WithGroup(name), messageFields: handler.messageFields}
+}
+
+// ----------------------------------------------------------------------------
+// slog.Leveler interface methods - sinkLeveler
+// ----------------------------------------------------------------------------
+
+func (leveler *sinkLeveler) Level() slog.Level {
+	if leveler.explicit.Load() {
+		return leveler.level.Level()
+	}
+
+	return leveler.loggerLeveler.Level()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods - sinkLeveler
+// ----------------------------------------------------------------------------
+
+// Set the level of the sink.  The sink no longer follows the level of the logger.
+func (leveler *sinkLeveler) set(level slog.Level) {
+	leveler.level.Set(level)
+	leveler.explicit.Store(true)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+/*
+Determine the message fields of each sink and the message fields the messenger needs to create.
+A sink without MessageFields gets the message fields of the logger.
+The messenger is given the message fields with each message, as SENZING_MESSAGE_FIELDS overrides OptionMessageFields.
+*/
+func constructSinkMessageFields(extractedValues *ExtractedValues) {
+	hasSinkMessageFields := false
+
+	for _, sink := range extractedValues.sinks {
+		if sink.MessageFields != nil {
+			hasSinkMessageFields = true
+		}
+	}
+
+	if !hasSinkMessageFields {
+		return
+	}
+
+	defaultMessageFields := extractedValues.messageFields
+	if defaultMessageFields == nil {
+		defaultMessageFields = messengerMessageFields(extractedValues.messengerOptions)
+	}
+
+	union := []string{}
+
+	for index := range extractedValues.sinks {
+		sink := &extractedValues.sinks[index]
+		if sink.MessageFields == nil {
+			sink.MessageFields = defaultMessageFields
+		}
+
+		union = append(union, sink.MessageFields...)
+	}
+
+	extractedValues.sinkMessageFields = []string{}
+
+	for _, messageField := range AllMessageFields {
+		if slices.Contains(union, messageField) {
+			extractedValues.sinkMessageFields = append(extractedValues.sinkMessageFields, messageField)
+		}
+	}
+
+	extractedValues.messageFields = extractedValues.sinkMessageFields
+}
+
+func isMessageField(key string) bool {
+	return slices.Contains(AllMessageFields, key)
+}
+
+/*
+The message fields a messenger writes when none are given, e.g. from SENZING_MESSAGE_FIELDS.
+The messenger resolves them; a message having every field shows which it chose.
+*/
+func messengerMessageFields(messengerOptions []interface{}) []string {
+	result := []string{}
+
+	probe, err := messenger.New(messengerOptions...)
+	if err != nil {
+		return result
+	}
+
+	message, _, keyValuePairs := probe.NewSlogLevel(
+		0,
+		"detail",
+		errForPackage,
+		messenger.MessageCode{Value: "code"},
+		messenger.MessageDuration{Value: 1},
+		messenger.MessageLocation{Value: "location"},
+		messenger.MessageReason{Value: "reason"},
+		messenger.MessageStatus{Value: "status"},
+		messenger.MessageText{Value: "text"},
+	)
+
+	keys := []string{}
+	if message != "" {
+		keys = append(keys, "text")
+	}
+
+	for index := 0; index < len(keyValuePairs); index += 2 {
+		if key, ok := keyValuePairs[index].(string); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, messageField := range AllMessageFields {
+		if slices.Contains(keys, messageField) {
+			result = append(result, messageField)
+		}
+	}
+
+	return result
+}
+
+// Create one slog.Handler per sink, each with its own leveler following the leveler of the logger.
+func newSinksHandler(
+	extractedValues *ExtractedValues,
+	loggerLeveler slog.Leveler,
+	options []interface{},
+) (slog.Handler, map[string]*sinkLeveler) {
+	handlers := make([]slog.Handler, 0, len(extractedValues.sinks))
+	sinkLevelers := map[string]*sinkLeveler{}
+
+	for _, sink := range extractedValues.sinks {
+		leveler := &sinkLeveler{loggerLeveler: loggerLeveler}
+
+		if sink.LogLevel != "" {
+			leveler.set(TextToLevelMap[sink.LogLevel])
+		}
+
+		if sink.Name != "" {
+			sinkLevelers[sink.Name] = leveler
+		}
+
+		var handler slog.Handler
+
+		switch {
+		case sink.Handler != nil:
+			handler = newLevelHandler(sink.Handler, leveler, SlogHandlerOptions(leveler, options...))
+		default:
+			output := sink.Output
+			if output == nil {
+				output = os.Stderr
+			}
+
+			format := sink.Format
+			if format == "" {
+				format = FormatJSON
+			}
+
+			handler = newFormatHandler(format, output, SlogHandlerOptions(leveler, options...))
+		}
+
+		if sink.MessageFields != nil {
+			handler = &fieldFilterHandler{handler: handler, messageFields: sink.MessageFields}
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	return &fanoutHandler{handlers: handlers}, sinkLevelers
+}
+
+func verifySinks(sinks []Sink) error {
+	names := map[string]bool{}
+
+	for _, sink := range sinks {
+		if sink.Name != "" {
+			if names[sink.Name] {
+				return wraperror.Errorf(errForPackage, "duplicate sink name: %s", sink.Name)
+			}
+
+			names[sink.Name] = true
+		}
+
+		if sink.Format != "" && !slices.Contains(AllFormats, sink.Format) {
+			return wraperror.Errorf(errForPackage, "unknown format: %s for sink: %s", sink.Format, sink.Name)
+		}
+
+		if sink.LogLevel != "" && !IsValidLogLevelName(sink.LogLevel) {
+			return wraperror.Errorf(errForPackage, "unknown error level: %s for sink: %s", sink.LogLevel, sink.Name)
+		}
+	}
+
+	return nil
+}
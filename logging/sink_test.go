@@ -0,0 +1,164 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionSinks(test *testing.T) {
+	test.Parallel()
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		logging.OptionSinks{Value: []logging.Sink{
+			{Name: "console", Format: logging.FormatLogfmt, Output: consoleString},
+			{Name: "file", LogLevel: logging.LevelTraceName, Output: fileString},
+		}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(1, "Bob", "Jane")
+	logger.Log(2001, "Bob", "Jane")
+	assert.Equal(test, "level=INFO text=\"INFO: Bob works with Jane\" id=2001\n", consoleString.String())
+	assert.Equal(
+		test,
+		`{"level":"TRACE","text":"TRACE: Bob works with Jane","id":"1"}`+"\n"+
+			`{"level":"INFO","text":"INFO: Bob works with Jane","id":"2001"}`+"\n",
+		fileString.String(),
+	)
+}
+
+func TestLogging_New_optionSinks_messageFields(test *testing.T) {
+	test.Parallel()
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		logging.OptionSink{Value: logging.Sink{Output: consoleString, MessageFields: []string{"text"}}},
+		logging.OptionSink{Value: logging.Sink{Output: fileString, MessageFields: []string{"id", "reason"}}},
+	)
+	require.NoError(test, err)
+
+	logger.(logging.AttrLogging).With("jobID", "job-20").Log(2001, "Bob", "Jane")
+	assert.Equal(test, `{"level":"INFO","text":"INFO: Bob works with Jane","jobID":"job-20"}`+"\n", consoleString.String())
+	assert.Equal(test, `{"level":"INFO","jobID":"job-20","id":"2001"}`+"\n", fileString.String())
+}
+
+func TestLogging_New_optionSinks_messageFieldsEnvironment(test *testing.T) {
+	test.Setenv("SENZING_MESSAGE_FIELDS", "id, reason")
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionIDMessages(),
+		getOptionTimeHidden(),
+		logging.OptionSink{Value: logging.Sink{Output: consoleString, MessageFields: []string{"text"}}},
+		logging.OptionSink{Value: logging.Sink{Output: fileString}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane", logging.MessageReason{Value: "A reason"})
+	assert.Equal(test, `{"level":"INFO","text":"INFO: Bob works with Jane"}`+"\n", consoleString.String())
+	assert.Equal(test, `{"level":"INFO","id":"2001","reason":"A reason"}`+"\n", fileString.String())
+}
+
+func TestLogging_New_optionSinks_handler(test *testing.T) {
+	test.Parallel()
+
+	handlerString := new(bytes.Buffer)
+	handler := slog.NewJSONHandler(handlerString, &slog.HandlerOptions{Level: logging.LevelTraceSlog})
+	logger, err := logging.New(
+		logging.OptionSink{Value: logging.Sink{Name: "handler", Handler: handler, LogLevel: logging.LevelWarnName}},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001)
+	assert.Empty(test, handlerString.String())
+	logger.Log(3001)
+	assert.Contains(test, handlerString.String(), `"id":"3001"`)
+}
+
+func TestLogging_New_optionSinks_badFormat(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionSink{Value: logging.Sink{Name: "bad", Format: "xml"}})
+	require.Error(test, err)
+}
+
+func TestLogging_New_optionSinks_badLogLevel(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionSink{Value: logging.Sink{Name: "bad", LogLevel: badLogLevelName}})
+	require.Error(test, err)
+}
+
+func TestLogging_New_optionSinks_duplicateName(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.New(logging.OptionSinks{Value: []logging.Sink{{Name: "same"}, {Name: "same"}}})
+	require.Error(test, err)
+}
+
+func TestLogging_SetSinkLogLevel(test *testing.T) {
+	test.Parallel()
+
+	consoleString := new(bytes.Buffer)
+	fileString := new(bytes.Buffer)
+	logger, err := logging.New(
+		getOptionTimeHidden(),
+		logging.OptionSinks{Value: []logging.Sink{
+			{Name: "console", Output: consoleString},
+			{Name: "file", LogLevel: logging.LevelTraceName, Output: fileString},
+		}},
+	)
+	require.NoError(test, err)
+	assert.True(test, logger.IsTrace())
+
+	sinkLogger := logger.(logging.SinkLogging)
+
+	logLevelName, err := sinkLogger.GetSinkLogLevel("file")
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelTraceName, logLevelName)
+
+	err = sinkLogger.SetSinkLogLevel("file", logging.LevelErrorName)
+	require.NoError(test, err)
+	assert.False(test, logger.IsDebug())
+	assert.True(test, logger.IsInfo())
+	logger.Log(3001)
+	logger.Log(4001)
+	assert.Equal(test, `{"level":"WARN","id":"3001"}`+"\n"+`{"level":"ERROR","id":"4001"}`+"\n", consoleString.String())
+	assert.Equal(test, `{"level":"ERROR","id":"4001"}`+"\n", fileString.String())
+
+	err = logger.SetLogLevel(logging.LevelDebugName)
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelDebugName, logger.GetLogLevel())
+
+	logLevelName, err = sinkLogger.GetSinkLogLevel("console")
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelDebugName, logLevelName)
+
+	logLevelName, err = sinkLogger.GetSinkLogLevel("file")
+	require.NoError(test, err)
+	assert.Equal(test, logging.LevelErrorName, logLevelName)
+
+	err = sinkLogger.SetSinkLogLevel("file", badLogLevelName)
+	require.Error(test, err)
+	err = sinkLogger.SetSinkLogLevel("unknown", logging.LevelDebugName)
+	require.Error(test, err)
+	_, err = sinkLogger.GetSinkLogLevel("unknown")
+	require.Error(test, err)
+}
@@ -0,0 +1,303 @@
+package logging
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The templateChecker type compares the details of messages to the fmt verbs of their templates when OptionStrict is used.
+type templateChecker struct {
+	diagnosticID int
+	templates    map[int][]templateVerb
+	violations   atomic.Uint64
+}
+
+// The templateVerb type is a fmt verb of a template and the 0-based position of the detail it formats.
+// A "*" width or precision is a verb formatting an integer detail.
+type templateVerb struct {
+	argIndex int
+	verb     rune
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The message number of the diagnostic logged, with OptionStrict, when details do not match a message template.
+const TemplateDiagnosticID = 3998
+
+// The fmt verbs allowed in message templates.
+const templateVerbs = "bcdeEfFgGoOpqstTUvxX"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The TemplateDetailCount function returns the number of details formatted by a message template.
+For templates having named placeholders, it is the highest position placeholder, e.g. 2 for "{2} comes after {1}",
+as the template is formatted when OptionPlaceholders is used.
+
+Input
+  - template: A message template, as with OptionIDMessages.
+
+Output
+  - The number of details formatted by the template.
+  - error: The template does not parse, as with OptionStrict.
+*/
+func TemplateDetailCount(template string) (int, error) {
+	var result int
+
+	if placeholderTemplate := parsePlaceholderTemplate(template); placeholderTemplate != nil {
+		for _, part := range placeholderTemplate.parts {
+			if position, err := strconv.Atoi(part.name); err == nil {
+				result = max(result, position)
+			}
+		}
+
+		return result, nil
+	}
+
+	verbs, err := parseTemplate(template)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, verb := range verbs {
+		result = max(result, verb.argIndex+1)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Returns true if the verb formats the detail without a "%!" error.
+func (verb templateVerb) accepts(detail interface{}) bool {
+	if verb.verb == '*' {
+		return !strings.Contains(fmt.Sprintf("%*d", detail, 0), "%!(BADWIDTH)")
+	}
+
+	return !strings.HasPrefix(fmt.Sprintf("%"+string(verb.verb), detail), "%!")
+}
+
+// The mismatches between the details and the fmt verbs of the message template.  Each mismatch is counted once.
+func (checker *templateChecker) check(messageNumber int, details []interface{}) []string {
+	if checker == nil {
+		return nil
+	}
+
+	verbs, ok := checker.templates[messageNumber]
+	if !ok {
+		return nil
+	}
+
+	var (
+		formatDetails = transformDetails(details...)
+		result        []string
+	)
+
+	for _, verb := range verbs {
+		if verb.argIndex >= len(formatDetails) {
+			result = append(result, fmt.Sprintf("no detail for %%%c at position %d", verb.verb, verb.argIndex+1))
+
+			continue
+		}
+
+		detail := formatDetails[verb.argIndex]
+		if !verb.accepts(detail) {
+			result = append(
+				result,
+				fmt.Sprintf("%%%c cannot format %T detail at position %d", verb.verb, detail, verb.argIndex+1),
+			)
+		}
+	}
+
+	if len(result) > 0 {
+		checker.violations.Add(1)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The fmt verbs of the message templates.  Nil unless OptionStrict is used.
+// With OptionPlaceholders, templates having named placeholders are checked by placeholders instead.
+func newTemplateChecker(extractedValues *ExtractedValues) *templateChecker {
+	if !extractedValues.strict {
+		return nil
+	}
+
+	result := &templateChecker{
+		diagnosticID: extractedValues.templateDiagnosticID,
+		templates:    map[int][]templateVerb{},
+	}
+
+	for messageNumber, text := range extractedValues.idMessages {
+		if extractedValues.placeholders && parsePlaceholderTemplate(text) != nil {
+			continue
+		}
+
+		verbs, err := parseTemplate(text)
+		if err == nil && len(verbs) > 0 {
+			result.templates[messageNumber] = verbs
+		}
+	}
+
+	return result
+}
+
+// An explicit argument index, e.g. "[2]", at the index of the template.  Returns the new argument and template indexes.
+func parseArgIndex(text string, index int, argIndex int) (int, int, error) {
+	if index >= len(text) || text[index] != '[' {
+		return argIndex, index, nil
+	}
+
+	end := strings.IndexByte(text[index:], ']')
+	if end < 0 {
+		return 0, 0, wraperror.Errorf(errForPackage, "unclosed argument index in %q", text)
+	}
+
+	position, err := strconv.Atoi(text[index+1 : index+end])
+	if err != nil || position < 1 {
+		return 0, 0, wraperror.Errorf(errForPackage, "bad argument index %s in %q", text[index:index+end+1], text)
+	}
+
+	return position - 1, index + end + 1, nil
+}
+
+// The fmt verbs of a message template, following the syntax of fmt.Printf.
+func parseTemplate(text string) ([]templateVerb, error) {
+	var (
+		argIndex int
+		err      error
+		result   []templateVerb
+	)
+
+	for index := 0; index < len(text); index++ {
+		if text[index] != '%' {
+			continue
+		}
+
+		index++
+		for index < len(text) && strings.IndexByte("+-# 0", text[index]) >= 0 {
+			index++
+		}
+
+		// Width, then precision, each optionally with an argument index.
+
+		for _, prefix := range []string{"", "."} {
+			if !strings.HasPrefix(text[index:], prefix) {
+				continue
+			}
+
+			index += len(prefix)
+
+			argIndex, index, err = parseArgIndex(text, index, argIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			if index < len(text) && text[index] == '*' {
+				result = append(result, templateVerb{argIndex: argIndex, verb: '*'})
+				argIndex++
+				index++
+
+				continue
+			}
+
+			for index < len(text) && text[index] >= '0' && text[index] <= '9' {
+				index++
+			}
+		}
+
+		argIndex, index, err = parseArgIndex(text, index, argIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		if index >= len(text) {
+			return nil, wraperror.Errorf(errForPackage, "no verb after %% at the end of %q", text)
+		}
+
+		verb, size := utf8.DecodeRuneInString(text[index:])
+		index += size - 1
+
+		switch {
+		case verb == '%':
+		case !strings.ContainsRune(templateVerbs, verb):
+			return nil, wraperror.Errorf(errForPackage, "unknown verb %%%c in %q", verb, text)
+		default:
+			result = append(result, templateVerb{argIndex: argIndex, verb: verb})
+			argIndex++
+		}
+	}
+
+	return result, nil
+}
+
+// With OptionStrict, every message template must parse and none may use the diagnostic message number.
+// Errors name the first bad message.
+func verifyTemplates(extractedValues *ExtractedValues) error {
+	if extractedValues.templateDiagnosticID < 0 {
+		return wraperror.Errorf(
+			errForPackage,
+			"template diagnostic message number must not be negative: %d",
+			extractedValues.templateDiagnosticID,
+		)
+	}
+
+	if !extractedValues.strict {
+		return nil
+	}
+
+	if _, ok := extractedValues.idMessages[extractedValues.templateDiagnosticID]; ok {
+		return wraperror.Errorf(
+			errForPackage,
+			"message %d is reserved for the template diagnostic; set OptionTemplateDiagnosticID",
+			extractedValues.templateDiagnosticID,
+		)
+	}
+
+	for _, messageNumber := range slices.Sorted(maps.Keys(extractedValues.idMessages)) {
+		text := extractedValues.idMessages[messageNumber]
+		verbs, err := parseTemplate(text)
+
+		if !extractedValues.placeholders || parsePlaceholderTemplate(text) == nil {
+			if err != nil {
+				return wraperror.Errorf(err, "message %d", messageNumber)
+			}
+
+			continue
+		}
+
+		// Templates having named placeholders are not formatted by fmt, so "%" is literal text in them.
+
+		if err == nil && len(verbs) > 0 {
+			return wraperror.Errorf(
+				errForPackage,
+				"message %d mixes fmt verbs and named placeholders: %q",
+				messageNumber,
+				text,
+			)
+		}
+	}
+
+	return nil
+}
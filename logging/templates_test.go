@@ -0,0 +1,141 @@
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idMessagesStrictTest = map[int]string{ //nolint
+	2001: "%s works with %s",
+	2002: "The favorite number for %s is %d.",
+	2003: "%[2]s comes after %[1]s, %[3]d%% done, %-*s.",
+	2004: "The favorite number for {entityID} is 100%.",
+}
+
+var optionStrictPlaceholders = []interface{}{ //nolint
+	logging.OptionPlaceholders{Value: true},
+	logging.OptionStrict{Value: true},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLogging_New_optionStrict(test *testing.T) {
+	test.Parallel()
+
+	_, err := logging.NewSenzingLogger(componentID, idMessagesStrictTest, optionStrictPlaceholders...)
+	require.NoError(test, err)
+
+	_, err = logging.NewSenzingLogger(componentID, idMessagesStrictTest, logging.OptionStrict{Value: true})
+	require.ErrorContains(test, err, "message 2004")
+
+	testCases := []struct {
+		name     string
+		template string
+	}{
+		{name: "noVerb", template: "Ends with %"},
+		{name: "unknownVerb", template: "Bad %z verb"},
+		{name: "badIndex", template: "Bad %[0]s index"},
+		{name: "unclosedIndex", template: "Bad %[1s index"},
+		{name: "mixed", template: "{entityID} is %d"},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			idMessages := map[int]string{2001: "%s works with %s", 2005: testCase.template}
+			_, err := logging.NewSenzingLogger(componentID, idMessages, optionStrictPlaceholders...)
+			require.ErrorContains(test, err, "message 2005")
+
+			_, err = logging.NewSenzingLogger(componentID, idMessages)
+			require.NoError(test, err)
+		})
+	}
+
+	_, err = logging.New(logging.OptionTemplateDiagnosticID{Value: -1})
+	require.Error(test, err)
+
+	idMessages := map[int]string{logging.TemplateDiagnosticID: "Defined by the caller"}
+	_, err = logging.NewSenzingLogger(componentID, idMessages, logging.OptionStrict{Value: true})
+	require.ErrorContains(test, err, "OptionTemplateDiagnosticID")
+
+	_, err = logging.NewSenzingLogger(
+		componentID,
+		idMessages,
+		logging.OptionStrict{Value: true},
+		logging.OptionTemplateDiagnosticID{Value: 4998},
+	)
+	require.NoError(test, err)
+}
+
+func TestLogging_Log_optionStrict(test *testing.T) {
+	test.Parallel()
+
+	outputString := new(bytes.Buffer)
+	logger, err := logging.NewSenzingLogger(
+		componentID,
+		idMessagesStrictTest,
+		getOptionTimeHidden(),
+		optionOutput(outputString),
+		logging.OptionMessageFields{Value: []string{"id", "text"}},
+		logging.OptionPlaceholders{Value: true},
+		logging.OptionStrict{Value: true},
+	)
+	require.NoError(test, err)
+
+	logger.Log(2001, "Bob", "Jane", errTest)
+	logger.Log(2003, "Bob", "Jane", 50, 8, "x")
+	assert.Equal(
+		test,
+		`{"level":"INFO","text":"Bob works with Jane","id":"SZTL99972001"}`+"\n"+
+			`{"level":"INFO","text":"Jane comes after Bob, 50% done, x       .","id":"SZTL99972003"}`+"\n",
+		outputString.String(),
+	)
+	assert.Zero(test, logger.(logging.StrictLogging).TemplateViolations())
+	outputString.Reset()
+
+	logger.Log(2002, "Robert Smith")
+	logger.(logging.AttrLogging).With("jobID", "job-20").Log(2002, "Robert Smith", "seven")
+	logger.Log(2003, "Bob", "Jane", 50, "eight", "x")
+	assert.Equal(
+		test,
+		`{"level":"WARN","text":"message 2002: no detail for %d at position 2","id":"SZTL99973998",`+
+			`"messageNumber":2002}`+"\n"+
+			`{"level":"INFO","text":"The favorite number for Robert Smith is {%!d(string=level)}.","id":"SZTL99972002"}`+"\n"+
+			`{"level":"WARN","text":"message 2002: %d cannot format string detail at position 2","jobID":"job-20",`+
+			`"id":"SZTL99973998","messageNumber":2002}`+"\n"+
+			`{"level":"INFO","text":"The favorite number for Robert Smith is %!d(string=seven).","jobID":"job-20",`+
+			`"id":"SZTL99972002"}`+"\n"+
+			`{"level":"WARN","text":"message 2003: %* cannot format string detail at position 4","id":"SZTL99973998",`+
+			`"messageNumber":2003}`+"\n"+
+			`{"level":"INFO","text":"Jane comes after Bob, 50% done, ","id":"SZTL99972003"}`+"\n",
+		outputString.String(),
+	)
+	assert.Equal(test, uint64(3), logger.(logging.StrictLogging).TemplateViolations())
+}
+
+func TestTemplateDetailCount(test *testing.T) {
+	test.Parallel()
+
+	for template, expected := range map[string]int{
+		"No details":                       0,
+		"%s works with %s":                 2,
+		"%[2]s comes after %[1]s, 100%%":   2,
+		"%-*s":                             2,
+		"{2} comes after {1}, {entityID}":  2,
+		"The number for {entityID} is 5%.": 0,
+	} {
+		actual, err := logging.TemplateDetailCount(template)
+		require.NoError(test, err)
+		assert.Equal(test, expected, actual, template)
+	}
+
+	_, err := logging.TemplateDetailCount("Ends with %")
+	require.Error(test, err)
+}
@@ -0,0 +1,17 @@
+/*
+Package loggingcheck is a go/analysis analyzer for calls to the Log, JSON, and NewError methods of logging.Logging
+and the LogContext and NewErrorContext methods of logging.ContextLogging.
+
+It reports:
+  - Message numbers missing from the message catalog of the package,
+    i.e. the map literal passed to NewSenzingLogger() or OptionIDMessages.
+    Catalogs given with OptionCatalog or OptionLocalizedCatalogs are loaded at run time, so their messages are unknown;
+    in a package using them, message numbers are not reported missing, and only the map literals are checked.
+  - Calls having fewer details than the message template formats, or extra string, number, or boolean details.
+  - Message numbers whose level, by IDLevelRangesAsString, contradicts the surrounding code,
+    e.g. an INFO message logged in an "if err != nil" block.
+  - Message numbers used with different details, which suggests one message number used for different meanings.
+
+Use it with "go vet -vettool=$(which loggingcheck)", see cmd/loggingcheck, or as a golangci-lint plugin with New().
+*/
+package loggingcheck
@@ -0,0 +1,507 @@
+package loggingcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"golang.org/x/exp/slog"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The callSite type is a checked call having a constant message number.
+type callSite struct {
+	call          *ast.CallExpr
+	details       []ast.Expr
+	ellipsis      bool
+	messageNumber int
+}
+
+/*
+The catalog type holds the message templates of the map literals
+passed to NewSenzingLogger() or OptionIDMessages in the package.
+Message numbers are only reported missing if every catalog of the package is a map literal with constant entries,
+so not in packages using OptionCatalog or OptionLocalizedCatalogs, whose messages are loaded at run time.
+*/
+type catalog struct {
+	complete  bool
+	found     bool
+	messages  map[int]string
+	positions map[int]token.Pos
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Add the entries of a map literal.  Message numbers given different templates are reported.
+func (catalog *catalog) add(pass *analysis.Pass, literal *ast.CompositeLit) {
+	catalog.found = true
+
+	for _, element := range literal.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			catalog.complete = false
+
+			continue
+		}
+
+		messageNumber, isInt := intValue(pass, keyValue.Key)
+		text, isString := stringValue(pass, keyValue.Value)
+
+		if !isInt || !isString {
+			catalog.complete = false
+
+			continue
+		}
+
+		if previous, ok := catalog.messages[messageNumber]; ok {
+			if previous != text {
+				pass.Reportf(
+					keyValue.Pos(),
+					"message %d has a different template at %s",
+					messageNumber,
+					position(pass, catalog.positions[messageNumber]),
+				)
+			}
+
+			continue
+		}
+
+		_, err := logging.TemplateDetailCount(text)
+		if err != nil {
+			pass.Reportf(keyValue.Value.Pos(), "message %d template does not parse: %s", messageNumber, text)
+		}
+
+		catalog.messages[messageNumber] = text
+		catalog.positions[messageNumber] = keyValue.Pos()
+	}
+}
+
+// Report a message number missing from the catalog and details not matching the template.
+func (catalog *catalog) check(pass *analysis.Pass, site callSite) {
+	if !catalog.found {
+		return
+	}
+
+	text, ok := catalog.messages[site.messageNumber]
+	if !ok {
+		if catalog.complete {
+			pass.Reportf(site.call.Pos(), "message %d is not in the message catalog", site.messageNumber)
+		}
+
+		return
+	}
+
+	count, err := logging.TemplateDetailCount(text)
+	if err != nil || site.ellipsis {
+		return
+	}
+
+	if len(site.details) < count {
+		pass.Reportf(
+			site.call.Pos(),
+			"message %d template formats %d details, but %d are given",
+			site.messageNumber,
+			count,
+			len(site.details),
+		)
+
+		return
+	}
+
+	if strings.Contains(text, "%") && hasExtraBasicDetail(pass, site.details[count:]) {
+		pass.Reportf(
+			site.call.Pos(),
+			"message %d template formats %d details, but more are given",
+			site.messageNumber,
+			count,
+		)
+	}
+}
+
+/*
+The types of the details giving the meaning of a message: those formatted by its template, if known,
+otherwise the leading string, number, and boolean details.
+Other details, e.g. errors, may differ between uses.
+False if the call has fewer details than the template formats, which is reported separately.
+*/
+func (catalog *catalog) meaning(pass *analysis.Pass, site callSite) ([]types.Type, bool) {
+	count := len(site.details)
+
+	if text, ok := catalog.messages[site.messageNumber]; ok {
+		templateCount, err := logging.TemplateDetailCount(text)
+		if err == nil && templateCount > count {
+			return nil, false
+		}
+
+		if err == nil {
+			count = templateCount
+		}
+	} else {
+		for index, detail := range site.details {
+			if _, ok := pass.TypesInfo.TypeOf(detail).(*types.Basic); !ok {
+				count = index
+
+				break
+			}
+		}
+	}
+
+	result := make([]types.Type, 0, count)
+	for _, detail := range site.details[:count] {
+		result = append(result, types.Default(pass.TypesInfo.TypeOf(detail)))
+	}
+
+	return result, true
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	astInspector, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	catalog := findCatalog(pass, astInspector)
+	sites := []callSite{}
+
+	astInspector.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		site, ok := newCallSite(pass, node.(*ast.CallExpr)) //nolint:forcetypeassert
+		if !ok {
+			return true
+		}
+
+		catalog.check(pass, site)
+		checkLevel(pass, site, stack)
+
+		sites = append(sites, site)
+
+		return true
+	})
+
+	checkMeanings(pass, catalog, sites)
+
+	return nil, nil //nolint:nilnil
+}
+
+// Report a message whose level contradicts an enclosing "if err != nil" or "if err == nil".
+func checkLevel(pass *analysis.Pass, site callSite, stack []ast.Node) {
+	name := levelName(site.messageNumber)
+	level := logging.TextToLevelMap[name]
+
+	for index := len(stack) - 2; index >= 0; index-- {
+		switch node := stack[index].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return
+		case *ast.IfStmt:
+			if stack[index+1] != node.Body {
+				continue
+			}
+
+			operator, ok := errorCondition(pass, node.Cond)
+			switch {
+			case !ok:
+				continue
+			case operator == token.NEQ && level < slog.LevelWarn:
+				pass.Reportf(
+					site.call.Pos(),
+					"message %d has level %s, but is used when err != nil",
+					site.messageNumber,
+					name,
+				)
+			case operator == token.EQL && level >= slog.LevelError:
+				pass.Reportf(
+					site.call.Pos(),
+					"message %d has level %s, but is used when err == nil",
+					site.messageNumber,
+					name,
+				)
+			}
+
+			return
+		}
+	}
+}
+
+// Report a message number used with details differing from its first use.
+func checkMeanings(pass *analysis.Pass, catalog *catalog, sites []callSite) {
+	type firstUse struct {
+		meaning []types.Type
+		site    callSite
+	}
+
+	first := map[int]firstUse{}
+
+	for _, site := range sites {
+		meaning, ok := catalog.meaning(pass, site)
+		if site.ellipsis || !ok {
+			continue
+		}
+
+		previous, ok := first[site.messageNumber]
+		if !ok {
+			first[site.messageNumber] = firstUse{meaning: meaning, site: site}
+
+			continue
+		}
+
+		if !sameTypes(previous.meaning, meaning) {
+			pass.Reportf(
+				site.call.Pos(),
+				"message %d is used with different details at %s; use another message number for another meaning",
+				site.messageNumber,
+				position(pass, previous.site.call.Pos()),
+			)
+		}
+	}
+}
+
+// The map literal an expression evaluates to, following variables initialized with a map literal.
+func compositeLiteral(pass *analysis.Pass, initializers map[types.Object]ast.Expr, expr ast.Expr) *ast.CompositeLit {
+	switch typedExpr := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		return typedExpr
+	case *ast.Ident:
+		if initializer, ok := initializers[pass.TypesInfo.Uses[typedExpr]]; ok {
+			return compositeLiteral(pass, nil, initializer)
+		}
+	}
+
+	return nil
+}
+
+// The operator of a condition comparing an error to nil, e.g. "err != nil".
+func errorCondition(pass *analysis.Pass, cond ast.Expr) (token.Token, bool) {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || (binary.Op != token.NEQ && binary.Op != token.EQL) {
+		return token.ILLEGAL, false
+	}
+
+	errorType := types.Universe.Lookup("error").Type()
+
+	for _, operands := range [][2]ast.Expr{{binary.X, binary.Y}, {binary.Y, binary.X}} {
+		operandType := pass.TypesInfo.TypeOf(operands[0])
+		if operandType != nil && types.Identical(operandType, errorType) && pass.TypesInfo.Types[operands[1]].IsNil() {
+			return binary.Op, true
+		}
+	}
+
+	return token.ILLEGAL, false
+}
+
+// The catalog of the package.
+func findCatalog(pass *analysis.Pass, astInspector *inspector.Inspector) *catalog {
+	result := &catalog{
+		complete:  true,
+		messages:  map[int]string{},
+		positions: map[int]token.Pos{},
+	}
+	initializers := variableInitializers(pass, astInspector)
+
+	var expressions []ast.Expr
+
+	astInspector.Preorder([]ast.Node{(*ast.CallExpr)(nil), (*ast.CompositeLit)(nil)}, func(node ast.Node) {
+		switch typedNode := node.(type) {
+		case *ast.CallExpr:
+			if isLoggingObject(typeutil.Callee(pass.TypesInfo, typedNode), "NewSenzingLogger") && len(typedNode.Args) > 1 {
+				expressions = append(expressions, typedNode.Args[1])
+			}
+		case *ast.CompositeLit:
+			if isLoggingType(pass.TypesInfo.TypeOf(typedNode), "OptionCatalog") ||
+				isLoggingType(pass.TypesInfo.TypeOf(typedNode), "OptionLocalizedCatalogs") {
+				result.complete = false
+			}
+
+			if isLoggingType(pass.TypesInfo.TypeOf(typedNode), "OptionIDMessages") && len(typedNode.Elts) == 1 {
+				value := typedNode.Elts[0]
+				if keyValue, ok := value.(*ast.KeyValueExpr); ok {
+					value = keyValue.Value
+				}
+
+				expressions = append(expressions, value)
+			}
+		}
+	})
+
+	for _, expr := range expressions {
+		if pass.TypesInfo.Types[expr].IsNil() {
+			continue
+		}
+
+		literal := compositeLiteral(pass, initializers, expr)
+		if literal == nil {
+			result.found = true
+			result.complete = false
+
+			continue
+		}
+
+		result.add(pass, literal)
+	}
+
+	return result
+}
+
+// Returns true if some of the details are strings, numbers, or booleans.
+func hasExtraBasicDetail(pass *analysis.Pass, details []ast.Expr) bool {
+	for _, detail := range details {
+		if basic, ok := pass.TypesInfo.TypeOf(detail).(*types.Basic); ok && basic.Kind() != types.UntypedNil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func intValue(pass *analysis.Pass, expr ast.Expr) (int, bool) {
+	value := pass.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.Int {
+		return 0, false
+	}
+
+	result, exact := constant.Int64Val(value)
+
+	return int(result), exact
+}
+
+func isLoggingObject(object types.Object, name string) bool {
+	return object != nil && object.Pkg() != nil && object.Pkg().Path() == loggingPath && object.Name() == name
+}
+
+func isLoggingType(objectType types.Type, name string) bool {
+	named, ok := objectType.(*types.Named)
+
+	return ok && isLoggingObject(named.Obj(), name)
+}
+
+// The level of a message number according to IDLevelRangesAsString.
+func levelName(messageNumber int) string {
+	result := logging.LevelPanicName
+	lowBound := -1
+
+	for rangeLowBound, name := range logging.IDLevelRangesAsString {
+		if rangeLowBound <= messageNumber && rangeLowBound > lowBound {
+			lowBound = rangeLowBound
+			result = name
+		}
+	}
+
+	return result
+}
+
+// A checked call, if the call is to a checked method and has a constant message number.
+func newCallSite(pass *analysis.Pass, call *ast.CallExpr) (callSite, bool) {
+	selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return callSite{}, false
+	}
+
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.MethodVal {
+		return callSite{}, false
+	}
+
+	index, ok := messageNumberIndexes[selection.Obj().Name()]
+	if !ok || !isLoggingObject(selection.Obj(), selection.Obj().Name()) || len(call.Args) <= index {
+		return callSite{}, false
+	}
+
+	messageNumber, ok := intValue(pass, call.Args[index])
+	if !ok {
+		return callSite{}, false
+	}
+
+	return callSite{
+		call:          call,
+		details:       call.Args[index+1:],
+		ellipsis:      call.Ellipsis.IsValid(),
+		messageNumber: messageNumber,
+	}, true
+}
+
+// A file name and line, e.g. "main.go:12".
+func position(pass *analysis.Pass, pos token.Pos) string {
+	result := pass.Fset.Position(pos)
+
+	return fmt.Sprintf("%s:%d", filepath.Base(result.Filename), result.Line)
+}
+
+// Returns true if the types are the same.  Interface types match any type.
+func sameTypes(types1 []types.Type, types2 []types.Type) bool {
+	if len(types1) != len(types2) {
+		return false
+	}
+
+	for index := range types1 {
+		if types.IsInterface(types1[index]) || types.IsInterface(types2[index]) {
+			continue
+		}
+
+		if !types.Identical(types1[index], types2[index]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringValue(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	value := pass.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(value), true
+}
+
+// The initial values of variables declared with "var" or ":=", for following catalogs given by name.
+func variableInitializers(pass *analysis.Pass, astInspector *inspector.Inspector) map[types.Object]ast.Expr {
+	result := map[types.Object]ast.Expr{}
+
+	astInspector.Preorder([]ast.Node{(*ast.ValueSpec)(nil), (*ast.AssignStmt)(nil)}, func(node ast.Node) {
+		var names, values []ast.Expr
+
+		switch typedNode := node.(type) {
+		case *ast.ValueSpec:
+			for _, name := range typedNode.Names {
+				names = append(names, name)
+			}
+
+			values = typedNode.Values
+		case *ast.AssignStmt:
+			if typedNode.Tok != token.DEFINE {
+				return
+			}
+
+			names, values = typedNode.Lhs, typedNode.Rhs
+		}
+
+		if len(names) != len(values) {
+			return
+		}
+
+		for index, name := range names {
+			if ident, ok := name.(*ast.Ident); ok && pass.TypesInfo.Defs[ident] != nil {
+				result[pass.TypesInfo.Defs[ident]] = values[index]
+			}
+		}
+	})
+
+	return result
+}
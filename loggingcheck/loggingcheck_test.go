@@ -0,0 +1,18 @@
+package loggingcheck_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-logging/loggingcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAnalyzer(test *testing.T) {
+	test.Parallel()
+
+	analysistest.Run(test, analysistest.TestData(), loggingcheck.Analyzer, "catalog", "example")
+}
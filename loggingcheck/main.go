@@ -0,0 +1,55 @@
+package loggingcheck
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// The import path of the package whose calls are checked.
+const loggingPath = "github.com/senzing-garage/go-logging/logging"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Analyzer checks the message numbers and details of logging.Logging calls.
+var Analyzer = &analysis.Analyzer{ //nolint
+	Name:     "loggingcheck",
+	Doc:      "check the message numbers and details of go-logging Log, JSON, and NewError calls",
+	URL:      "https://pkg.go.dev/github.com/senzing-garage/go-logging/loggingcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// The position of the message number in the arguments of each checked method.
+var messageNumberIndexes = map[string]int{ //nolint
+	"JSON":            0,
+	"Log":             0,
+	"LogContext":      1,
+	"NewError":        0,
+	"NewErrorContext": 1,
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The New function returns the analyzers of the package, with the signature of a golangci-lint plugin.
+
+Input
+  - conf: The plugin settings of the golangci-lint configuration.  Not used.
+
+Output
+  - The Analyzer.
+  - error
+*/
+func New(conf any) ([]*analysis.Analyzer, error) {
+	_ = conf
+
+	return []*analysis.Analyzer{Analyzer}, nil
+}
@@ -0,0 +1,15 @@
+package catalog
+
+import "github.com/senzing-garage/go-logging/logging"
+
+var idMessages = map[int]string{
+	2001: "Started %s",
+}
+
+func logs(catalog *logging.Catalog) {
+	logger, _ := logging.NewSenzingLogger(9999, idMessages, logging.OptionCatalog{Value: catalog})
+
+	logger.Log(2001, "job-20")
+	logger.Log(2001) // want `message 2001 template formats 1 details, but 0 are given`
+	logger.Log(4001, "file.txt")
+}
@@ -0,0 +1,57 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+)
+
+const messageStarted = 2001
+
+var idMessages = map[int]string{
+	2001: "Started %s",
+	2002: "The favorite number for %s is %d.",
+	2003: "{2} comes after {1}.",
+	2004: "The favorite number for {entityID} is {number}.",
+	4001: "Cannot read %s",
+	4002: "Bad %z verb", // want `message 4002 template does not parse: Bad %z verb`
+}
+
+var errExample = errors.New("example")
+
+func logs(ctx context.Context, details []interface{}) {
+	logger, _ := logging.NewSenzingLogger(9999, idMessages)
+
+	logger.Log(messageStarted, "job-20")
+	logger.Log(2002, "Robert Smith")             // want `message 2002 template formats 2 details, but 1 are given`
+	logger.Log(2002, "Robert Smith", 7, "extra") // want `message 2002 template formats 2 details, but more are given`
+	logger.Log(2002, "Robert Smith", 7, errExample, time.Second)
+	logger.Log(2002, details...)
+	logger.Log(2003, "Bob") // want `message 2003 template formats 2 details, but 1 are given`
+	logger.Log(2004, map[string]string{"entityID": "Bob", "number": "7"})
+	logger.(logging.ContextLogging).LogContext(ctx, 2005) // want `message 2005 is not in the message catalog`
+	_ = logger.JSON(4001, "file.txt")
+	_ = logger.(logging.ContextLogging).NewErrorContext(ctx, 4001, "other.txt")
+}
+
+func levels(logger logging.Logging) error {
+	err := errExample
+	if err != nil {
+		logger.Log(2001, "job-20") // want `message 2001 has level INFO, but is used when err != nil`
+
+		return logger.NewError(4001, "file.txt", err)
+	}
+
+	if err == nil {
+		return logger.NewError(4001, "file.txt") // want `message 4001 has level ERROR, but is used when err == nil`
+	}
+
+	return nil
+}
+
+func meanings(logger logging.Logging) {
+	logger.JSON(2001, "job-20")
+	logger.JSON(2001, 20) // want `message 2001 is used with different details at example.go:27; use another message number for another meaning`
+}
@@ -0,0 +1,12 @@
+package example
+
+import "github.com/senzing-garage/go-logging/logging"
+
+func option() {
+	logger, _ := logging.New(logging.OptionIDMessages{Value: map[int]string{
+		2001: "Started %s",
+		4001: "Cannot open %s", // want `message 4001 has a different template at example.go:18`
+	}})
+
+	logger.Log(2001, "job-21")
+}
@@ -0,0 +1,35 @@
+// Package logging is a stub of the methods and options checked by loggingcheck.
+package logging
+
+import "context"
+
+type Logging interface {
+	JSON(messageNumber int, details ...interface{}) string
+	Log(messageNumber int, details ...interface{})
+	NewError(messageNumber int, details ...interface{}) error
+}
+
+type ContextLogging interface {
+	LogContext(ctx context.Context, messageNumber int, details ...interface{})
+	NewErrorContext(ctx context.Context, messageNumber int, details ...interface{}) error
+}
+
+type Catalog struct {
+	Messages map[int]string
+}
+
+type OptionCatalog struct {
+	Value *Catalog
+}
+
+type OptionIDMessages struct {
+	Value map[int]string
+}
+
+func New(options ...interface{}) (Logging, error) {
+	return nil, nil
+}
+
+func NewSenzingLogger(componentID int, idMessages map[int]string, options ...interface{}) (Logging, error) {
+	return nil, nil
+}